@@ -0,0 +1,4 @@
+package repositories
+
+// GroupName is the group name use in this package
+const GroupName = "repositories.stash.appscode.com"
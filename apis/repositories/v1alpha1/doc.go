@@ -0,0 +1,6 @@
+// Package v1alpha1 is the v1alpha1 version of the API.
+
+// +k8s:deepcopy-gen=package,register
+// +k8s:openapi-gen=true
+// +groupName=repositories.stash.appscode.com
+package v1alpha1
@@ -0,0 +1,52 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindSnapshot     = "Snapshot"
+	ResourceNameSnapshot     = "snapshot"
+	ResourceTypeSnapshot     = "snapshots"
+	ResourceKindSnapshotList = "SnapshotList"
+)
+
+// Labels of a Snapshot. These can be used in label selectors.
+const (
+	LabelRepository   = "repository"
+	LabelRestic       = "restic"
+	LabelWorkloadKind = "workload-kind"
+	LabelWorkloadName = "workload-name"
+	LabelHostname     = "hostname"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Snapshot is a read-only view of a restic snapshot stored in a repository.
+type Snapshot struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Status            SnapshotStatus `json:"status,omitempty"`
+}
+
+type SnapshotStatus struct {
+	// ID of the snapshot in restic repository
+	ID       string   `json:"id"`
+	Tree     string   `json:"tree"`
+	Paths    []string `json:"paths"`
+	Hostname string   `json:"hostname"`
+	Username string   `json:"username"`
+	UID      int      `json:"uid"`
+	Gid      int      `json:"gid"`
+	Tags     []string `json:"tags,omitempty"`
+	// Repository is the path prefix of the restic repository inside backend
+	Repository string `json:"repository"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type SnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Snapshot `json:"items,omitempty"`
+}
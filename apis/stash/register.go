@@ -33,6 +33,14 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ResticList{},
 		&Recovery{},
 		&RecoveryList{},
+		&Repository{},
+		&RepositoryList{},
+		&BackupSession{},
+		&BackupSessionList{},
+		&BackupTemplate{},
+		&BackupTemplateList{},
+		&ClusterRetentionPolicy{},
+		&ClusterRetentionPolicyList{},
 	)
 	return nil
 }
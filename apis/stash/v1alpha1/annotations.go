@@ -4,4 +4,7 @@ const (
 	ResticKey                = "restic.appscode.com"
 	LastAppliedConfiguration = ResticKey + "/last-applied-configuration"
 	VersionTag               = ResticKey + "/tag"
+	// PendingRollout is set on StatefulSets whose pods are being replaced after the sidecar was
+	// added or removed.
+	PendingRollout = ResticKey + "/pending-rollout"
 )
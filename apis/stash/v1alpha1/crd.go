@@ -26,6 +26,26 @@ func (c Restic) CustomResourceDefinition() *apiextensions.CustomResourceDefiniti
 	}
 }
 
+func (c BackupSession) CustomResourceDefinition() *apiextensions.CustomResourceDefinition {
+	return &apiextensions.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   sapi.ResourceTypeBackupSession + "." + SchemeGroupVersion.Group,
+			Labels: map[string]string{"app": "stash"},
+		},
+		Spec: apiextensions.CustomResourceDefinitionSpec{
+			Group:   sapi.GroupName,
+			Version: SchemeGroupVersion.Version,
+			Scope:   apiextensions.NamespaceScoped,
+			Names: apiextensions.CustomResourceDefinitionNames{
+				Singular:   sapi.ResourceNameBackupSession,
+				Plural:     sapi.ResourceTypeBackupSession,
+				Kind:       sapi.ResourceKindBackupSession,
+				ShortNames: []string{"bs"},
+			},
+		},
+	}
+}
+
 func (c Recovery) CustomResourceDefinition() *apiextensions.CustomResourceDefinition {
 	return &apiextensions.CustomResourceDefinition{
 		ObjectMeta: metav1.ObjectMeta{
@@ -45,3 +65,63 @@ func (c Recovery) CustomResourceDefinition() *apiextensions.CustomResourceDefini
 		},
 	}
 }
+
+func (c Repository) CustomResourceDefinition() *apiextensions.CustomResourceDefinition {
+	return &apiextensions.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   sapi.ResourceTypeRepository + "." + SchemeGroupVersion.Group,
+			Labels: map[string]string{"app": "stash"},
+		},
+		Spec: apiextensions.CustomResourceDefinitionSpec{
+			Group:   sapi.GroupName,
+			Version: SchemeGroupVersion.Version,
+			Scope:   apiextensions.NamespaceScoped,
+			Names: apiextensions.CustomResourceDefinitionNames{
+				Singular:   sapi.ResourceNameRepository,
+				Plural:     sapi.ResourceTypeRepository,
+				Kind:       sapi.ResourceKindRepository,
+				ShortNames: []string{"repo"},
+			},
+		},
+	}
+}
+
+func (c BackupTemplate) CustomResourceDefinition() *apiextensions.CustomResourceDefinition {
+	return &apiextensions.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   sapi.ResourceTypeBackupTemplate + "." + SchemeGroupVersion.Group,
+			Labels: map[string]string{"app": "stash"},
+		},
+		Spec: apiextensions.CustomResourceDefinitionSpec{
+			Group:   sapi.GroupName,
+			Version: SchemeGroupVersion.Version,
+			Scope:   apiextensions.ClusterScoped,
+			Names: apiextensions.CustomResourceDefinitionNames{
+				Singular:   sapi.ResourceNameBackupTemplate,
+				Plural:     sapi.ResourceTypeBackupTemplate,
+				Kind:       sapi.ResourceKindBackupTemplate,
+				ShortNames: []string{"bt"},
+			},
+		},
+	}
+}
+
+func (c ClusterRetentionPolicy) CustomResourceDefinition() *apiextensions.CustomResourceDefinition {
+	return &apiextensions.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   sapi.ResourceTypeClusterRetentionPolicy + "." + SchemeGroupVersion.Group,
+			Labels: map[string]string{"app": "stash"},
+		},
+		Spec: apiextensions.CustomResourceDefinitionSpec{
+			Group:   sapi.GroupName,
+			Version: SchemeGroupVersion.Version,
+			Scope:   apiextensions.ClusterScoped,
+			Names: apiextensions.CustomResourceDefinitionNames{
+				Singular:   sapi.ResourceNameClusterRetentionPolicy,
+				Plural:     sapi.ResourceTypeClusterRetentionPolicy,
+				Kind:       sapi.ResourceKindClusterRetentionPolicy,
+				ShortNames: []string{"crp"},
+			},
+		},
+	}
+}
@@ -1,6 +1,9 @@
 package v1alpha1
 
 import (
+	"path/filepath"
+	"strings"
+
 	core "k8s.io/api/core/v1"
 )
 
@@ -16,3 +19,108 @@ func (l LocalSpec) ToVolumeAndMount(volName string) (core.Volume, core.VolumeMou
 	}
 	return vol, mnt
 }
+
+// IsSubPath checks whether path is dir or inside dir.
+func IsSubPath(dir, path string) bool {
+	rel, err := filepath.Rel(filepath.Clean(dir), filepath.Clean(path))
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, "../")
+}
+
+// RestoreTargetFor returns the RestoreTarget for a backed up path. If no target
+// is specified for the path, it is restored into the same path.
+func (r RecoverySpec) RestoreTargetFor(path string) RestoreTarget {
+	for _, t := range r.Targets {
+		if t.Path == path {
+			if t.TargetPath == "" {
+				t.TargetPath = path
+			}
+			return t
+		}
+	}
+	return RestoreTarget{Path: path, TargetPath: path}
+}
+
+// Resolved returns a copy of the Restic with the values of status.template set where the
+// Restic leaves them empty, and the retention policies of the template and the cluster
+// retention policies in status appended unless the Restic has a policy with the same name.
+func (r *Restic) Resolved() *Restic {
+	out := r.DeepCopy()
+	if t := r.Status.Template; t != nil {
+		if t.Backend != nil && out.Spec.Backend == (Backend{StorageSecretName: out.Spec.Backend.StorageSecretName}) {
+			secret := out.Spec.Backend.StorageSecretName
+			out.Spec.Backend = *t.Backend.DeepCopy()
+			if secret != "" {
+				out.Spec.Backend.StorageSecretName = secret
+			}
+		}
+		if out.Spec.Schedule == "" {
+			out.Spec.Schedule = t.Schedule
+		}
+		out.Spec.Resources.Limits = mergeResources(out.Spec.Resources.Limits, t.Resources.Limits)
+		out.Spec.Resources.Requests = mergeResources(out.Spec.Resources.Requests, t.Resources.Requests)
+		out.Spec.RetentionPolicies = appendRetentionPolicies(out.Spec.RetentionPolicies, t.RetentionPolicies)
+	}
+	out.Spec.RetentionPolicies = appendRetentionPolicies(out.Spec.RetentionPolicies, r.Status.ClusterRetentionPolicies)
+	return out
+}
+
+func mergeResources(list, defaults core.ResourceList) core.ResourceList {
+	for name, quantity := range defaults {
+		if _, found := list[name]; !found {
+			if list == nil {
+				list = core.ResourceList{}
+			}
+			list[name] = quantity.DeepCopy()
+		}
+	}
+	return list
+}
+
+func appendRetentionPolicies(policies, defaults []RetentionPolicy) []RetentionPolicy {
+outer:
+	for _, d := range defaults {
+		for _, p := range policies {
+			if p.Name == d.Name {
+				continue outer
+			}
+		}
+		policies = append(policies, *d.DeepCopy())
+	}
+	return policies
+}
+
+// CalculatePhase returns the phase of a BackupSession from the status of its hosts. The session is
+// not finished before the operator has recorded the expected hosts and all of them have finished.
+func (s BackupSessionStatus) CalculatePhase() BackupSessionPhase {
+	finished := make(map[string]bool)
+	failed := false
+	for _, host := range s.Hosts {
+		switch host.Phase {
+		case BackupSessionSucceeded:
+			finished[host.Hostname] = true
+		case BackupSessionFailed:
+			finished[host.Hostname] = true
+			failed = true
+		default:
+			return BackupSessionRunning
+		}
+	}
+	if len(s.ExpectedHosts) == 0 {
+		if len(s.Hosts) == 0 {
+			return BackupSessionPending
+		}
+		return BackupSessionRunning
+	}
+	for _, hostname := range s.ExpectedHosts {
+		if !finished[hostname] {
+			if len(s.Hosts) == 0 {
+				return BackupSessionPending
+			}
+			return BackupSessionRunning
+		}
+	}
+	if failed {
+		return BackupSessionFailed
+	}
+	return BackupSessionSucceeded
+}
@@ -36,6 +36,14 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ResticList{},
 		&Recovery{},
 		&RecoveryList{},
+		&Repository{},
+		&RepositoryList{},
+		&BackupSession{},
+		&BackupSessionList{},
+		&BackupTemplate{},
+		&BackupTemplateList{},
+		&ClusterRetentionPolicy{},
+		&ClusterRetentionPolicyList{},
 	)
 
 	scheme.AddKnownTypes(SchemeGroupVersion,
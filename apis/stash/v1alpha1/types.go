@@ -12,6 +12,22 @@ const (
 	ResourceKindRecovery = "Recovery"
 	ResourceNameRecovery = "recovery"
 	ResourceTypeRecovery = "recoveries"
+
+	ResourceKindRepository = "Repository"
+	ResourceNameRepository = "repository"
+	ResourceTypeRepository = "repositories"
+
+	ResourceKindBackupSession = "BackupSession"
+	ResourceNameBackupSession = "backupsession"
+	ResourceTypeBackupSession = "backupsessions"
+
+	ResourceKindBackupTemplate = "BackupTemplate"
+	ResourceNameBackupTemplate = "backuptemplate"
+	ResourceTypeBackupTemplate = "backuptemplates"
+
+	ResourceKindClusterRetentionPolicy = "ClusterRetentionPolicy"
+	ResourceNameClusterRetentionPolicy = "clusterretentionpolicy"
+	ResourceTypeClusterRetentionPolicy = "clusterretentionpolicies"
 )
 
 // +genclient
@@ -30,6 +46,14 @@ type ResticSpec struct {
 	FileGroups []FileGroup          `json:"fileGroups,omitempty"`
 	Backend    Backend              `json:"backend,omitempty"`
 	Schedule   string               `json:"schedule,omitempty"`
+	// Template is the name of a BackupTemplate providing the backend, schedule, resources and
+	// retention policies not set in this Restic.
+	// +optional
+	Template string `json:"template,omitempty"`
+	// ScheduleJitter delays scheduled backups of each host by an offset between zero and this
+	// duration, derived from the hostname, so that hosts do not start backups at the same time.
+	// +optional
+	ScheduleJitter *metav1.Duration `json:"scheduleJitter,omitempty"`
 	// Pod volumes to mount into the sidecar container's filesystem.
 	VolumeMounts []core.VolumeMount `json:"volumeMounts,omitempty"`
 	// Compute Resources required by the sidecar container.
@@ -37,6 +61,35 @@ type ResticSpec struct {
 	RetentionPolicies []RetentionPolicy         `json:"retentionPolicies,omitempty"`
 	// https://github.com/appscode/stash/issues/225
 	Type BackupType `json:"type,omitempty"`
+	// PreBackup is executed before each backup. Only supported for online backup.
+	// +optional
+	PreBackup *BackupHook `json:"preBackup,omitempty"`
+	// PostBackup is executed after each backup, even if the backup or PreBackup failed.
+	// Only supported for online backup.
+	// +optional
+	PostBackup *BackupHook `json:"postBackup,omitempty"`
+	// Maintenance schedules maintenance of the repositories written by this Restic.
+	// +optional
+	Maintenance *MaintenanceSpec `json:"maintenance,omitempty"`
+	// Bandwidth limits the network bandwidth used by restic. Limits not set here default to
+	// the limits set in the operator.
+	// +optional
+	Bandwidth *BandwidthLimit `json:"bandwidth,omitempty"`
+	// FailurePolicy decides whether a backup stops at the first fileGroup that fails.
+	// Defaults to FailFast.
+	// +optional
+	FailurePolicy FailurePolicy `json:"failurePolicy,omitempty"`
+	// Parallelism is the maximum number of fileGroups backed up at the same time. FileGroups
+	// under the same volume mount are backed up one after another. Defaults to 1.
+	// +optional
+	Parallelism int32 `json:"parallelism,omitempty"`
+	// Timeout of each backup attempt. Restic is killed when it expires, so that a backup stuck
+	// on an unresponsive volume or backend does not block later backups.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Retry retries failed backups.
+	// +optional
+	Retry *RetryPolicy `json:"retry,omitempty"`
 }
 
 type ResticStatus struct {
@@ -45,6 +98,15 @@ type ResticStatus struct {
 	LastSuccessfulBackupTime *metav1.Time `json:"lastSuccessfulBackupTime,omitempty"`
 	LastBackupDuration       string       `json:"lastBackupDuration,omitempty"`
 	BackupCount              int64        `json:"backupCount,omitempty"`
+	// Template is a copy of the spec of the BackupTemplate referenced by spec.template, and
+	// ClusterRetentionPolicies of the ClusterRetentionPolicies referenced by fileGroups. They
+	// are kept up to date by the operator, as sidecars can't read cluster-scoped resources.
+	Template                 *BackupTemplateSpec `json:"template,omitempty"`
+	ClusterRetentionPolicies []RetentionPolicy   `json:"clusterRetentionPolicies,omitempty"`
+	// TemplateError is the error of the last update of Template and ClusterRetentionPolicies,
+	// eg. if the BackupTemplate is not found. Empty if the last update succeeded. Sidecars keep
+	// using the last copies until it is resolved.
+	TemplateError string `json:"templateError,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -62,18 +124,60 @@ type FileGroup struct {
 	Tags []string `json:"tags,omitempty"`
 	// retention policy of snapshots
 	RetentionPolicyName string `json:"retentionPolicyName,omitempty"`
+	// Stdin backs up the output of a command as a file named Path, instead of the files under Path.
+	// +optional
+	Stdin *StdinSource `json:"stdin,omitempty"`
+	// Bandwidth overrides spec.bandwidth for backups of this fileGroup.
+	// +optional
+	Bandwidth *BandwidthLimit `json:"bandwidth,omitempty"`
+	// Excludes skips files and directories matching these patterns. Patterns use the syntax
+	// of restic --exclude, which is also used by include and exclude of Recovery targets.
+	// +optional
+	Excludes []string `json:"excludes,omitempty"`
+	// ExcludeFile is the path of a file in the sidecar containing exclude patterns, one per line.
+	// +optional
+	ExcludeFile string `json:"excludeFile,omitempty"`
+	// ExcludeCaches skips the contents of directories containing a CACHEDIR.TAG file.
+	// +optional
+	ExcludeCaches bool `json:"excludeCaches,omitempty"`
+	// OneFileSystem skips directories that are mount points of other filesystems.
+	// +optional
+	OneFileSystem bool `json:"oneFileSystem,omitempty"`
+	// Includes backs up only the files and directories under Path matching these patterns,
+	// and the directories containing them. Patterns use the same syntax as Excludes, except
+	// that relative patterns are relative to Path instead of matching at any depth.
+	// +optional
+	Includes []string `json:"includes,omitempty"`
+}
+
+// BandwidthLimit limits the network bandwidth used by restic.
+type BandwidthLimit struct {
+	// Upload limits uploads to this rate in KiB/s. Uploads are not limited if zero.
+	Upload int32 `json:"upload,omitempty"`
+	// Download limits downloads to this rate in KiB/s. Downloads are not limited if zero.
+	Download int32 `json:"download,omitempty"`
+}
+
+// StdinSource is a command whose stdout is backed up, eg. a database dump.
+type StdinSource struct {
+	// Command whose stdout is backed up. A non-zero exit code fails the backup.
+	Command []string `json:"command"`
+	// Container of the workload pod where Command is run using exec. If empty, Command is run in stash sidecar.
+	// +optional
+	Container string `json:"container,omitempty"`
 }
 
 type Backend struct {
 	StorageSecretName string `json:"storageSecretName,omitempty"`
 
-	Local *LocalSpec `json:"local,omitempty"`
-	S3    *S3Spec    `json:"s3,omitempty"`
-	GCS   *GCSSpec   `json:"gcs,omitempty"`
-	Azure *AzureSpec `json:"azure,omitempty"`
-	Swift *SwiftSpec `json:"swift,omitempty"`
-	B2    *B2Spec    `json:"b2,omitempty"`
-	// Rest  *RestServerSpec `json:"rest,omitempty"`
+	Local *LocalSpec      `json:"local,omitempty"`
+	S3    *S3Spec         `json:"s3,omitempty"`
+	GCS   *GCSSpec        `json:"gcs,omitempty"`
+	Azure *AzureSpec      `json:"azure,omitempty"`
+	Swift *SwiftSpec      `json:"swift,omitempty"`
+	B2    *B2Spec         `json:"b2,omitempty"`
+	Rest  *RestServerSpec `json:"rest,omitempty"`
+	SFTP  *SFTPSpec       `json:"sftp,omitempty"`
 }
 
 type LocalSpec struct {
@@ -86,6 +190,15 @@ type S3Spec struct {
 	Endpoint string `json:"endpoint,omitempty"`
 	Bucket   string `json:"bucket,omitempty"`
 	Prefix   string `json:"prefix,omitempty"`
+	// Region of the bucket. Required by some S3 compatible storages.
+	// +optional
+	Region string `json:"region,omitempty"`
+	// ForcePathStyle uses path-style addressing (endpoint/bucket) instead of virtual-hosted style.
+	// +optional
+	ForcePathStyle bool `json:"forcePathStyle,omitempty"`
+	// StorageClass of the objects uploaded by restic. Example: STANDARD_IA
+	// +optional
+	StorageClass string `json:"storageClass,omitempty"`
 }
 
 type GCSSpec struct {
@@ -110,6 +223,18 @@ type B2Spec struct {
 
 type RestServerSpec struct {
 	URL string `json:"url,omitempty"`
+	// AppendOnly indicates that the rest-server is running in append-only mode.
+	// Old snapshots are not forgotten for an append-only repository.
+	AppendOnly bool `json:"appendOnly,omitempty"`
+}
+
+type SFTPSpec struct {
+	Host string `json:"host,omitempty"`
+	// Port of the SSH server. Defaults to 22.
+	Port int    `json:"port,omitempty"`
+	User string `json:"user,omitempty"`
+	// Path of the directory on the SSH server where repository will be created.
+	Path string `json:"path,omitempty"`
 }
 
 type BackupType string
@@ -119,6 +244,95 @@ const (
 	BackupOffline BackupType = "offline" // injects init container
 )
 
+type FailurePolicy string
+
+const (
+	FailFast    FailurePolicy = "FailFast"    // default, skips remaining fileGroups after a failure
+	ContinueAll FailurePolicy = "ContinueAll" // backs up all fileGroups and reports every failure
+)
+
+// RetryPolicy retries a failed backup after a delay, which is doubled after each attempt.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of a backup, including the first one.
+	MaxAttempts int32 `json:"maxAttempts,omitempty"`
+	// Backoff is the delay before the first retry. Defaults to 30s.
+	// +optional
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+}
+
+// BackupHook is an action executed by the sidecar before or after a backup. Exactly one of
+// Exec and HTTPGet must be specified.
+type BackupHook struct {
+	// Exec runs a command inside a container of the workload pod.
+	// +optional
+	Exec *core.ExecAction `json:"exec,omitempty"`
+	// Container where Exec is run. Defaults to the first container of the pod other than stash sidecar.
+	// +optional
+	Container string `json:"container,omitempty"`
+	// HTTPGet sends a GET request. Host defaults to localhost, ie, the workload pod.
+	// +optional
+	HTTPGet *core.HTTPGetAction `json:"httpGet,omitempty"`
+	// Timeout of the hook. Defaults to 30s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// OnFailure decides whether a failure of this hook fails the backup. Defaults to Fail.
+	// A timed out Exec hook always fails the backup, since the command may still be running.
+	// +optional
+	OnFailure HookFailurePolicy `json:"onFailure,omitempty"`
+}
+
+type HookFailurePolicy string
+
+const (
+	HookFailurePolicyFail   HookFailurePolicy = "Fail"   // default, backup is marked as failed
+	HookFailurePolicyIgnore HookFailurePolicy = "Ignore" // failure is recorded in events only
+)
+
+// MaintenanceSpec schedules maintenance tasks of repositories. Each task runs in a Job created by
+// the operator, once for every Repository of the Restic. A task without schedule is not run,
+// except check which defaults to DefaultCheckSchedule.
+type MaintenanceSpec struct {
+	// Check verifies the integrity of the repository.
+	// +optional
+	Check *CheckSpec `json:"check,omitempty"`
+	// Prune removes data that is not referenced by any snapshot.
+	// +optional
+	Prune *MaintenanceTaskSpec `json:"prune,omitempty"`
+	// Unlock removes stale locks left behind by restic processes that were killed.
+	// +optional
+	Unlock *MaintenanceTaskSpec `json:"unlock,omitempty"`
+	// RebuildIndex rebuilds the index of the repository.
+	// +optional
+	RebuildIndex *MaintenanceTaskSpec `json:"rebuildIndex,omitempty"`
+}
+
+type MaintenanceTaskSpec struct {
+	// Schedule in cron format
+	Schedule string `json:"schedule,omitempty"`
+}
+
+type CheckSpec struct {
+	// Schedule in cron format
+	Schedule string `json:"schedule,omitempty"`
+	// ReadDataSubsets splits the data of the repository into this many subsets. Each check reads
+	// the next subset using --read-data-subset, so that all data is read once every ReadDataSubsets
+	// checks. Data is not read if zero.
+	// +optional
+	ReadDataSubsets int32 `json:"readDataSubsets,omitempty"`
+}
+
+// DefaultCheckSchedule is used to check repositories of Restics without spec.maintenance.check.
+const DefaultCheckSchedule = "0 0 */3 * *"
+
+type MaintenanceTask string
+
+const (
+	MaintenanceTaskCheck        MaintenanceTask = "check"
+	MaintenanceTaskPrune        MaintenanceTask = "prune"
+	MaintenanceTaskUnlock       MaintenanceTask = "unlock"
+	MaintenanceTaskRebuildIndex MaintenanceTask = "rebuild-index"
+)
+
 type RetentionStrategy string
 
 const (
@@ -129,6 +343,7 @@ const (
 	KeepMonthly RetentionStrategy = "--keep-monthly"
 	KeepYearly  RetentionStrategy = "--keep-yearly"
 	KeepTag     RetentionStrategy = "--keep-tag"
+	KeepWithin  RetentionStrategy = "--keep-within"
 )
 
 type RetentionPolicy struct {
@@ -140,10 +355,26 @@ type RetentionPolicy struct {
 	KeepMonthly int      `json:"keepMonthly,omitempty"`
 	KeepYearly  int      `json:"keepYearly,omitempty"`
 	KeepTags    []string `json:"keepTags,omitempty"`
-	Prune       bool     `json:"prune,omitempty"`
-	DryRun      bool     `json:"dryRun,omitempty"`
+	// KeepWithin keeps all snapshots taken within this duration before the newest snapshot,
+	// in the format of restic, eg. 1y2m3d4h.
+	// +optional
+	KeepWithin string `json:"keepWithin,omitempty"`
+	// GroupBy groups snapshots by host, paths and/or tags before applying the policy.
+	// Defaults to host and paths.
+	// +optional
+	GroupBy []SnapshotGroupBy `json:"groupBy,omitempty"`
+	Prune   bool              `json:"prune,omitempty"`
+	DryRun  bool              `json:"dryRun,omitempty"`
 }
 
+type SnapshotGroupBy string
+
+const (
+	GroupByHost  SnapshotGroupBy = "host"
+	GroupByPaths SnapshotGroupBy = "paths"
+	GroupByTags  SnapshotGroupBy = "tags"
+)
+
 // +genclient
 // +k8s:openapi-gen=true
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -162,6 +393,41 @@ type RecoverySpec struct {
 	PodOrdinal       string              `json:"podOrdinal,omitempty"`
 	NodeName         string              `json:"nodeName,omitempty"`
 	RecoveredVolumes []LocalSpec         `json:"recoveredVolumes,omitempty"`
+	// Snapshot is the ID of the restic snapshot to restore. If empty, the latest snapshot is restored.
+	Snapshot string `json:"snapshot,omitempty"`
+	// PointInTime selects the newest snapshot taken at or before this time. Can't be used with Snapshot.
+	PointInTime *metav1.Time `json:"pointInTime,omitempty"`
+	// Tags restricts snapshot selection to snapshots having all of these tags.
+	Tags []string `json:"tags,omitempty"`
+	// Targets customizes where and which files are restored for individual paths.
+	// Paths without a target are restored into the same path they were backed up from.
+	Targets []RestoreTarget `json:"targets,omitempty"`
+	// Bandwidth limits the network bandwidth used by restic. Limits not set here default to
+	// the limits set in the operator.
+	// +optional
+	Bandwidth *BandwidthLimit `json:"bandwidth,omitempty"`
+}
+
+// RestoreTarget specifies how a backed up path is restored.
+type RestoreTarget struct {
+	// Path is one of the paths of the Recovery.
+	Path string `json:"path"`
+	// TargetPath is the directory where Path is restored. It must be inside one of the recoveredVolumes.
+	// Defaults to Path.
+	// +optional
+	TargetPath string `json:"targetPath,omitempty"`
+	// Include restores only the files matching these patterns. Relative patterns are relative
+	// to Path, like Includes of a FileGroup.
+	// +optional
+	Include []string `json:"include,omitempty"`
+	// Exclude skips the files matching these patterns. Relative patterns are relative to Path.
+	// +optional
+	Exclude []string `json:"exclude,omitempty"`
+	// Command reads the content of Path from stdin. Used for paths backed up using stdin.
+	// It is run in the recovery job container instead of restoring files. The container uses
+	// the stash image, so only commands of its base image (alpine) are available.
+	// +optional
+	Command []string `json:"command,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -207,3 +473,200 @@ type RestoreStats struct {
 	Phase    RecoveryPhase `json:"phase,omitempty"`
 	Duration string        `json:"duration,omitempty"`
 }
+
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Repository records a restic repository created by a Stash sidecar. There is one
+// Repository for each prefix inside a backend, ie, one per Deployment and one per
+// pod of a StatefulSet or node of a DaemonSet.
+type Repository struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              RepositorySpec   `json:"spec,omitempty"`
+	Status            RepositoryStatus `json:"status,omitempty"`
+}
+
+type RepositorySpec struct {
+	// Backend where the repository is stored.
+	Backend Backend `json:"backend,omitempty"`
+	// Prefix is the path of the repository inside backend, eg. deployment/stash-demo
+	Prefix string `json:"prefix,omitempty"`
+	// Workload whose volumes are backed up into this repository.
+	Workload LocalTypedReference `json:"workload,omitempty"`
+	// Hostname used in snapshots of this repository.
+	Hostname string `json:"hostname,omitempty"`
+	// Restic used to take backups into this repository.
+	Restic string `json:"restic,omitempty"`
+}
+
+type RepositoryStatus struct {
+	FirstBackupTime          *metav1.Time `json:"firstBackupTime,omitempty"`
+	LastBackupTime           *metav1.Time `json:"lastBackupTime,omitempty"`
+	LastSuccessfulBackupTime *metav1.Time `json:"lastSuccessfulBackupTime,omitempty"`
+	LastBackupDuration       string       `json:"lastBackupDuration,omitempty"`
+	BackupCount              int64        `json:"backupCount,omitempty"`
+	// SnapshotCount is the number of snapshots in the repository after the last backup.
+	SnapshotCount int64 `json:"snapshotCount,omitempty"`
+	// Size is the total size of data stored in the repository in bytes.
+	Size int64 `json:"size,omitempty"`
+	// History contains the results of the last backups into this repository, newest first.
+	// At most BackupHistoryLimit backups are kept.
+	History []HostBackupStatus `json:"history,omitempty"`
+	// Maintenance contains the result of the last run of each maintenance task.
+	Maintenance []MaintenanceStatus `json:"maintenance,omitempty"`
+}
+
+type MaintenanceStatus struct {
+	Task                  MaintenanceTask `json:"task,omitempty"`
+	LastRunTime           *metav1.Time    `json:"lastRunTime,omitempty"`
+	LastSuccessfulRunTime *metav1.Time    `json:"lastSuccessfulRunTime,omitempty"`
+	LastRunDuration       string          `json:"lastRunDuration,omitempty"`
+	// Error of the last run, empty if it succeeded.
+	Error string `json:"error,omitempty"`
+	// ReadDataSubset is the subset of data read by the last check, eg. 2/5.
+	ReadDataSubset string `json:"readDataSubset,omitempty"`
+}
+
+// BackupHistoryLimit is the number of backups kept in the history of a Repository.
+const BackupHistoryLimit = 10
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type RepositoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Repository `json:"items,omitempty"`
+}
+
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BackupSession triggers a backup by the sidecars of a Restic outside its schedule.
+// Each sidecar records its result in the status of the session.
+type BackupSession struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              BackupSessionSpec   `json:"spec,omitempty"`
+	Status            BackupSessionStatus `json:"status,omitempty"`
+}
+
+type BackupSessionSpec struct {
+	// Restic whose sidecars take the backup. Must be in the same namespace.
+	Restic string `json:"restic,omitempty"`
+}
+
+type BackupSessionPhase string
+
+const (
+	BackupSessionPending   BackupSessionPhase = "Pending"
+	BackupSessionRunning   BackupSessionPhase = "Running"
+	BackupSessionSucceeded BackupSessionPhase = "Succeeded"
+	BackupSessionFailed    BackupSessionPhase = "Failed"
+)
+
+type BackupSessionStatus struct {
+	// Phase is Running until all expected hosts and all hosts that picked up the session have finished.
+	Phase BackupSessionPhase `json:"phase,omitempty"`
+	// ExpectedHosts are the hosts of the pods running the sidecar of the Restic when the session
+	// was created. They are recorded by the operator.
+	ExpectedHosts []string           `json:"expectedHosts,omitempty"`
+	Hosts         []HostBackupStatus `json:"hosts,omitempty"`
+}
+
+type HostBackupStatus struct {
+	// Hostname used in snapshots, ie, workload name, pod name of a StatefulSet or node name of a DaemonSet.
+	Hostname   string                  `json:"hostname,omitempty"`
+	Phase      BackupSessionPhase      `json:"phase,omitempty"`
+	StartTime  *metav1.Time            `json:"startTime,omitempty"`
+	EndTime    *metav1.Time            `json:"endTime,omitempty"`
+	FileGroups []FileGroupBackupStatus `json:"fileGroups,omitempty"`
+	Error      string                  `json:"error,omitempty"`
+}
+
+type FileGroupBackupStatus struct {
+	Path     string             `json:"path,omitempty"`
+	Phase    BackupSessionPhase `json:"phase,omitempty"`
+	Duration string             `json:"duration,omitempty"`
+	Error    string             `json:"error,omitempty"`
+	// SnapshotID is the ID of the restic snapshot taken for this fileGroup.
+	SnapshotID string `json:"snapshotID,omitempty"`
+	// DataAdded is the number of bytes added to the repository.
+	DataAdded int64 `json:"dataAdded,omitempty"`
+	// TotalBytesProcessed is the size of the files backed up in bytes, including unmodified files.
+	TotalBytesProcessed int64 `json:"totalBytesProcessed,omitempty"`
+	FilesNew            int64 `json:"filesNew,omitempty"`
+	FilesChanged        int64 `json:"filesChanged,omitempty"`
+	FilesUnmodified     int64 `json:"filesUnmodified,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type BackupSessionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BackupSession `json:"items,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BackupTemplate provides defaults shared by Restics of all namespaces, which reference it by
+// name in spec.template. Values set in a Restic override the template.
+type BackupTemplate struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              BackupTemplateSpec `json:"spec,omitempty"`
+}
+
+type BackupTemplateSpec struct {
+	// Backend used by Restics without a backend. StorageSecretName refers to a Secret in the
+	// namespace of each Restic, and is overridden by the Restic if set there.
+	// +optional
+	Backend *Backend `json:"backend,omitempty"`
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+	// Compute Resources required by the sidecar container. A Restic overrides the resources it sets.
+	// +optional
+	Resources core.ResourceRequirements `json:"resources,omitempty"`
+	// RetentionPolicies available to fileGroups, unless the Restic has a policy with the same name.
+	// +optional
+	RetentionPolicies []RetentionPolicy `json:"retentionPolicies,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type BackupTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BackupTemplate `json:"items,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterRetentionPolicy is a retention policy available to fileGroups of Restics in all
+// namespaces, unless the Restic or its BackupTemplate has a policy with the same name.
+type ClusterRetentionPolicy struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Spec is the retention policy. spec.name is ignored, fileGroups refer to the policy by
+	// the name of this object.
+	Spec RetentionPolicy `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ClusterRetentionPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterRetentionPolicy `json:"items,omitempty"`
+}
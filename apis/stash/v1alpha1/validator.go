@@ -2,12 +2,49 @@ package v1alpha1
 
 import (
 	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
 
 	"gopkg.in/robfig/cron.v2"
 )
 
 func (r Restic) IsValid() error {
 	for i, fg := range r.Spec.FileGroups {
+		if err := fg.Bandwidth.validate(fmt.Sprintf("spec.fileGroups[%d].bandwidth", i)); err != nil {
+			return err
+		}
+		if fg.Stdin != nil {
+			if len(fg.Stdin.Command) == 0 {
+				return fmt.Errorf("spec.fileGroups[%d].stdin.command is empty", i)
+			}
+			if !filepath.IsAbs(fg.Path) {
+				return fmt.Errorf("spec.fileGroups[%d].path %s must be an absolute path", i, fg.Path)
+			}
+			if fg.Stdin.Container != "" && r.Spec.Type == BackupOffline {
+				return fmt.Errorf("spec.fileGroups[%d].stdin.container is not supported for offline backup", i)
+			}
+			if len(fg.Excludes) > 0 || len(fg.Includes) > 0 || fg.ExcludeFile != "" || fg.ExcludeCaches || fg.OneFileSystem {
+				return fmt.Errorf("spec.fileGroups[%d] excludes, includes and filesystem options are not supported for stdin", i)
+			}
+		}
+		if fg.ExcludeFile != "" && !filepath.IsAbs(fg.ExcludeFile) {
+			return fmt.Errorf("spec.fileGroups[%d].excludeFile %s must be an absolute path", i, fg.ExcludeFile)
+		}
+		for j, pattern := range fg.Excludes {
+			if strings.TrimSpace(pattern) == "" {
+				return fmt.Errorf("spec.fileGroups[%d].excludes[%d] is empty", i, j)
+			}
+		}
+		for j, pattern := range fg.Includes {
+			if strings.TrimSpace(pattern) == "" {
+				return fmt.Errorf("spec.fileGroups[%d].includes[%d] is empty", i, j)
+			}
+			if filepath.IsAbs(pattern) && !IsSubPath(fg.Path, pattern) {
+				return fmt.Errorf("spec.fileGroups[%d].includes[%d] %s is not under path %s", i, j, pattern, fg.Path)
+			}
+		}
+
 		if fg.RetentionPolicyName == "" {
 			continue
 		}
@@ -24,13 +61,152 @@ func (r Restic) IsValid() error {
 		}
 	}
 
+	for i, policy := range r.Spec.RetentionPolicies {
+		if err := policy.validate(fmt.Sprintf("spec.retentionPolicies[%d]", i)); err != nil {
+			return err
+		}
+	}
+
 	_, err := cron.Parse(r.Spec.Schedule)
 	if err != nil {
 		return fmt.Errorf("spec.schedule %s is invalid. Reason: %s", r.Spec.Schedule, err)
 	}
+	if r.Spec.ScheduleJitter != nil && r.Spec.ScheduleJitter.Duration < 0 {
+		return fmt.Errorf("spec.scheduleJitter must not be negative")
+	}
 	if r.Spec.Backend.StorageSecretName == "" {
 		return fmt.Errorf("missing repository secret name")
 	}
+	switch r.Spec.FailurePolicy {
+	case "", FailFast, ContinueAll:
+	default:
+		return fmt.Errorf("spec.failurePolicy %s is invalid, must be %s or %s", r.Spec.FailurePolicy, FailFast, ContinueAll)
+	}
+	if r.Spec.Parallelism < 0 {
+		return fmt.Errorf("spec.parallelism must not be negative")
+	}
+	if r.Spec.Timeout != nil && r.Spec.Timeout.Duration < 0 {
+		return fmt.Errorf("spec.timeout must not be negative")
+	}
+	if r.Spec.Retry != nil {
+		if r.Spec.Retry.MaxAttempts < 1 {
+			return fmt.Errorf("spec.retry.maxAttempts must be at least 1")
+		}
+		if r.Spec.Retry.Backoff != nil && r.Spec.Retry.Backoff.Duration < 0 {
+			return fmt.Errorf("spec.retry.backoff must not be negative")
+		}
+	}
+
+	if (r.Spec.PreBackup != nil || r.Spec.PostBackup != nil) && r.Spec.Type == BackupOffline {
+		return fmt.Errorf("spec.preBackup and spec.postBackup are not supported for offline backup")
+	}
+	if err := r.Spec.PreBackup.validate("spec.preBackup"); err != nil {
+		return err
+	}
+	if err := r.Spec.PostBackup.validate("spec.postBackup"); err != nil {
+		return err
+	}
+	if err := r.Spec.Bandwidth.validate("spec.bandwidth"); err != nil {
+		return err
+	}
+	return r.Spec.Maintenance.validate("spec.maintenance")
+}
+
+// keepWithinRegex matches durations accepted by restic forget --keep-within.
+var keepWithinRegex = regexp.MustCompile(`^([0-9]+[ymdh])+$`)
+
+func (p RetentionPolicy) validate(field string) error {
+	if p.KeepWithin != "" && !keepWithinRegex.MatchString(p.KeepWithin) {
+		return fmt.Errorf("%s.keepWithin %s is invalid, must be a duration like 1y2m3d4h", field, p.KeepWithin)
+	}
+	for i, g := range p.GroupBy {
+		switch g {
+		case GroupByHost, GroupByPaths, GroupByTags:
+		default:
+			return fmt.Errorf("%s.groupBy[%d] %s is invalid, must be one of %s, %s or %s", field, i, g, GroupByHost, GroupByPaths, GroupByTags)
+		}
+	}
+	return nil
+}
+
+func (b *BandwidthLimit) validate(field string) error {
+	if b == nil {
+		return nil
+	}
+	if b.Upload < 0 || b.Download < 0 {
+		return fmt.Errorf("%s must not be negative", field)
+	}
+	return nil
+}
+
+func (m *MaintenanceSpec) validate(field string) error {
+	if m == nil {
+		return nil
+	}
+	if m.Check != nil {
+		if err := validateSchedule(field+".check.schedule", m.Check.Schedule); err != nil {
+			return err
+		}
+		if m.Check.ReadDataSubsets < 0 {
+			return fmt.Errorf("%s.check.readDataSubsets must not be negative", field)
+		}
+	}
+	if m.Prune != nil {
+		if err := validateSchedule(field+".prune.schedule", m.Prune.Schedule); err != nil {
+			return err
+		}
+	}
+	if m.Unlock != nil {
+		if err := validateSchedule(field+".unlock.schedule", m.Unlock.Schedule); err != nil {
+			return err
+		}
+	}
+	if m.RebuildIndex != nil {
+		if err := validateSchedule(field+".rebuildIndex.schedule", m.RebuildIndex.Schedule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateSchedule(field, schedule string) error {
+	if schedule == "" {
+		return nil
+	}
+	if _, err := cron.Parse(schedule); err != nil {
+		return fmt.Errorf("%s %s is invalid. Reason: %s", field, schedule, err)
+	}
+	return nil
+}
+
+func (h *BackupHook) validate(field string) error {
+	if h == nil {
+		return nil
+	}
+	if (h.Exec == nil) == (h.HTTPGet == nil) {
+		return fmt.Errorf("%s must specify exactly one of exec and httpGet", field)
+	}
+	if h.Exec != nil && len(h.Exec.Command) == 0 {
+		return fmt.Errorf("%s.exec.command is empty", field)
+	}
+	if h.HTTPGet != nil && h.Container != "" {
+		return fmt.Errorf("%s.container can't be used with httpGet", field)
+	}
+	if h.Timeout != nil && h.Timeout.Duration <= 0 {
+		return fmt.Errorf("%s.timeout must be positive", field)
+	}
+	switch h.OnFailure {
+	case "", HookFailurePolicyFail, HookFailurePolicyIgnore:
+	default:
+		return fmt.Errorf("%s.onFailure %s is invalid. Must be one of %s or %s", field, h.OnFailure, HookFailurePolicyFail, HookFailurePolicyIgnore)
+	}
+	return nil
+}
+
+func (s BackupSession) IsValid() error {
+	if s.Spec.Restic == "" {
+		return fmt.Errorf("missing spec.restic")
+	}
 	return nil
 }
 
@@ -45,6 +221,16 @@ func (r Recovery) IsValid() error {
 		return fmt.Errorf("missing recovery vollume")
 	}
 
+	if r.Spec.Snapshot != "" && r.Spec.PointInTime != nil {
+		return fmt.Errorf("should not specify both snapshot and pointInTime")
+	}
+	if err := r.validateTargets(); err != nil {
+		return err
+	}
+	if err := r.Spec.Bandwidth.validate("spec.bandwidth"); err != nil {
+		return err
+	}
+
 	if err := r.Spec.Workload.Canonicalize(); err != nil {
 		return err
 	}
@@ -71,3 +257,61 @@ func (r Recovery) IsValid() error {
 	}
 	return nil
 }
+
+func (r Recovery) validateTargets() error {
+	found := map[string]bool{}
+	for i, t := range r.Spec.Targets {
+		if found[t.Path] {
+			return fmt.Errorf("spec.targets[%d].path %s is duplicate", i, t.Path)
+		}
+		found[t.Path] = true
+
+		valid := false
+		for _, p := range r.Spec.Paths {
+			if p == t.Path {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("spec.targets[%d].path %s is not found in spec.paths", i, t.Path)
+		}
+
+		if len(t.Command) > 0 {
+			if t.TargetPath != "" || len(t.Include) > 0 || len(t.Exclude) > 0 {
+				return fmt.Errorf("spec.targets[%d].command can't be used with targetPath, include or exclude", i)
+			}
+			continue
+		}
+		if len(t.Include) > 0 && len(t.Exclude) > 0 {
+			return fmt.Errorf("spec.targets[%d].include can't be used with exclude", i)
+		}
+		for j, pattern := range t.Include {
+			if filepath.IsAbs(pattern) && !IsSubPath(t.Path, pattern) {
+				return fmt.Errorf("spec.targets[%d].include[%d] %s is not under path %s", i, j, pattern, t.Path)
+			}
+		}
+		for j, pattern := range t.Exclude {
+			if filepath.IsAbs(pattern) && !IsSubPath(t.Path, pattern) {
+				return fmt.Errorf("spec.targets[%d].exclude[%d] %s is not under path %s", i, j, pattern, t.Path)
+			}
+		}
+		if t.TargetPath == "" {
+			continue
+		}
+		if !filepath.IsAbs(t.TargetPath) {
+			return fmt.Errorf("spec.targets[%d].targetPath %s must be an absolute path", i, t.TargetPath)
+		}
+		mounted := false
+		for _, vol := range r.Spec.RecoveredVolumes {
+			if IsSubPath(vol.MountPath, t.TargetPath) {
+				mounted = true
+				break
+			}
+		}
+		if !mounted {
+			return fmt.Errorf("spec.targets[%d].targetPath %s is not inside any of spec.recoveredVolumes", i, t.TargetPath)
+		}
+	}
+	return nil
+}
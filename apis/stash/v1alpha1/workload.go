@@ -61,6 +61,11 @@ func (workload LocalTypedReference) HostnamePrefix(podName, nodeName string) (ho
 	return
 }
 
+// RepositoryName returns the name of the Repository object for the repository stored under prefix.
+func RepositoryName(prefix string) string {
+	return strings.Replace(prefix, "/", ".", -1)
+}
+
 func StatefulSetPodName(appName, podOrdinal string) (string, error) {
 	if appName == "" || podOrdinal == "" {
 		return "", fmt.Errorf("missing appName or podOrdinal")
@@ -44,14 +44,48 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 		Convert_stash_B2Spec_To_v1alpha1_B2Spec,
 		Convert_v1alpha1_Backend_To_stash_Backend,
 		Convert_stash_Backend_To_v1alpha1_Backend,
+		Convert_v1alpha1_BackupHook_To_stash_BackupHook,
+		Convert_stash_BackupHook_To_v1alpha1_BackupHook,
+		Convert_v1alpha1_BackupSession_To_stash_BackupSession,
+		Convert_stash_BackupSession_To_v1alpha1_BackupSession,
+		Convert_v1alpha1_BackupSessionList_To_stash_BackupSessionList,
+		Convert_stash_BackupSessionList_To_v1alpha1_BackupSessionList,
+		Convert_v1alpha1_BackupSessionSpec_To_stash_BackupSessionSpec,
+		Convert_stash_BackupSessionSpec_To_v1alpha1_BackupSessionSpec,
+		Convert_v1alpha1_BackupSessionStatus_To_stash_BackupSessionStatus,
+		Convert_stash_BackupSessionStatus_To_v1alpha1_BackupSessionStatus,
+		Convert_v1alpha1_BackupTemplate_To_stash_BackupTemplate,
+		Convert_stash_BackupTemplate_To_v1alpha1_BackupTemplate,
+		Convert_v1alpha1_BackupTemplateList_To_stash_BackupTemplateList,
+		Convert_stash_BackupTemplateList_To_v1alpha1_BackupTemplateList,
+		Convert_v1alpha1_BackupTemplateSpec_To_stash_BackupTemplateSpec,
+		Convert_stash_BackupTemplateSpec_To_v1alpha1_BackupTemplateSpec,
+		Convert_v1alpha1_BandwidthLimit_To_stash_BandwidthLimit,
+		Convert_stash_BandwidthLimit_To_v1alpha1_BandwidthLimit,
+		Convert_v1alpha1_CheckSpec_To_stash_CheckSpec,
+		Convert_stash_CheckSpec_To_v1alpha1_CheckSpec,
+		Convert_v1alpha1_ClusterRetentionPolicy_To_stash_ClusterRetentionPolicy,
+		Convert_stash_ClusterRetentionPolicy_To_v1alpha1_ClusterRetentionPolicy,
+		Convert_v1alpha1_ClusterRetentionPolicyList_To_stash_ClusterRetentionPolicyList,
+		Convert_stash_ClusterRetentionPolicyList_To_v1alpha1_ClusterRetentionPolicyList,
 		Convert_v1alpha1_FileGroup_To_stash_FileGroup,
 		Convert_stash_FileGroup_To_v1alpha1_FileGroup,
+		Convert_v1alpha1_FileGroupBackupStatus_To_stash_FileGroupBackupStatus,
+		Convert_stash_FileGroupBackupStatus_To_v1alpha1_FileGroupBackupStatus,
 		Convert_v1alpha1_GCSSpec_To_stash_GCSSpec,
 		Convert_stash_GCSSpec_To_v1alpha1_GCSSpec,
+		Convert_v1alpha1_HostBackupStatus_To_stash_HostBackupStatus,
+		Convert_stash_HostBackupStatus_To_v1alpha1_HostBackupStatus,
 		Convert_v1alpha1_LocalSpec_To_stash_LocalSpec,
 		Convert_stash_LocalSpec_To_v1alpha1_LocalSpec,
 		Convert_v1alpha1_LocalTypedReference_To_stash_LocalTypedReference,
 		Convert_stash_LocalTypedReference_To_v1alpha1_LocalTypedReference,
+		Convert_v1alpha1_MaintenanceSpec_To_stash_MaintenanceSpec,
+		Convert_stash_MaintenanceSpec_To_v1alpha1_MaintenanceSpec,
+		Convert_v1alpha1_MaintenanceStatus_To_stash_MaintenanceStatus,
+		Convert_stash_MaintenanceStatus_To_v1alpha1_MaintenanceStatus,
+		Convert_v1alpha1_MaintenanceTaskSpec_To_stash_MaintenanceTaskSpec,
+		Convert_stash_MaintenanceTaskSpec_To_v1alpha1_MaintenanceTaskSpec,
 		Convert_v1alpha1_Recovery_To_stash_Recovery,
 		Convert_stash_Recovery_To_v1alpha1_Recovery,
 		Convert_v1alpha1_RecoveryList_To_stash_RecoveryList,
@@ -60,6 +94,14 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 		Convert_stash_RecoverySpec_To_v1alpha1_RecoverySpec,
 		Convert_v1alpha1_RecoveryStatus_To_stash_RecoveryStatus,
 		Convert_stash_RecoveryStatus_To_v1alpha1_RecoveryStatus,
+		Convert_v1alpha1_Repository_To_stash_Repository,
+		Convert_stash_Repository_To_v1alpha1_Repository,
+		Convert_v1alpha1_RepositoryList_To_stash_RepositoryList,
+		Convert_stash_RepositoryList_To_v1alpha1_RepositoryList,
+		Convert_v1alpha1_RepositorySpec_To_stash_RepositorySpec,
+		Convert_stash_RepositorySpec_To_v1alpha1_RepositorySpec,
+		Convert_v1alpha1_RepositoryStatus_To_stash_RepositoryStatus,
+		Convert_stash_RepositoryStatus_To_v1alpha1_RepositoryStatus,
 		Convert_v1alpha1_RestServerSpec_To_stash_RestServerSpec,
 		Convert_stash_RestServerSpec_To_v1alpha1_RestServerSpec,
 		Convert_v1alpha1_Restic_To_stash_Restic,
@@ -72,10 +114,18 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 		Convert_stash_ResticStatus_To_v1alpha1_ResticStatus,
 		Convert_v1alpha1_RestoreStats_To_stash_RestoreStats,
 		Convert_stash_RestoreStats_To_v1alpha1_RestoreStats,
+		Convert_v1alpha1_RestoreTarget_To_stash_RestoreTarget,
+		Convert_stash_RestoreTarget_To_v1alpha1_RestoreTarget,
 		Convert_v1alpha1_RetentionPolicy_To_stash_RetentionPolicy,
 		Convert_stash_RetentionPolicy_To_v1alpha1_RetentionPolicy,
+		Convert_v1alpha1_RetryPolicy_To_stash_RetryPolicy,
+		Convert_stash_RetryPolicy_To_v1alpha1_RetryPolicy,
 		Convert_v1alpha1_S3Spec_To_stash_S3Spec,
 		Convert_stash_S3Spec_To_v1alpha1_S3Spec,
+		Convert_v1alpha1_SFTPSpec_To_stash_SFTPSpec,
+		Convert_stash_SFTPSpec_To_v1alpha1_SFTPSpec,
+		Convert_v1alpha1_StdinSource_To_stash_StdinSource,
+		Convert_stash_StdinSource_To_v1alpha1_StdinSource,
 		Convert_v1alpha1_SwiftSpec_To_stash_SwiftSpec,
 		Convert_stash_SwiftSpec_To_v1alpha1_SwiftSpec,
 	)
@@ -133,6 +183,8 @@ func autoConvert_v1alpha1_Backend_To_stash_Backend(in *Backend, out *stash.Backe
 	out.Azure = (*stash.AzureSpec)(unsafe.Pointer(in.Azure))
 	out.Swift = (*stash.SwiftSpec)(unsafe.Pointer(in.Swift))
 	out.B2 = (*stash.B2Spec)(unsafe.Pointer(in.B2))
+	out.Rest = (*stash.RestServerSpec)(unsafe.Pointer(in.Rest))
+	out.SFTP = (*stash.SFTPSpec)(unsafe.Pointer(in.SFTP))
 	return nil
 }
 
@@ -149,6 +201,8 @@ func autoConvert_stash_Backend_To_v1alpha1_Backend(in *stash.Backend, out *Backe
 	out.Azure = (*AzureSpec)(unsafe.Pointer(in.Azure))
 	out.Swift = (*SwiftSpec)(unsafe.Pointer(in.Swift))
 	out.B2 = (*B2Spec)(unsafe.Pointer(in.B2))
+	out.Rest = (*RestServerSpec)(unsafe.Pointer(in.Rest))
+	out.SFTP = (*SFTPSpec)(unsafe.Pointer(in.SFTP))
 	return nil
 }
 
@@ -157,10 +211,309 @@ func Convert_stash_Backend_To_v1alpha1_Backend(in *stash.Backend, out *Backend,
 	return autoConvert_stash_Backend_To_v1alpha1_Backend(in, out, s)
 }
 
+func autoConvert_v1alpha1_BackupHook_To_stash_BackupHook(in *BackupHook, out *stash.BackupHook, s conversion.Scope) error {
+	out.Exec = (*v1.ExecAction)(unsafe.Pointer(in.Exec))
+	out.Container = in.Container
+	out.HTTPGet = (*v1.HTTPGetAction)(unsafe.Pointer(in.HTTPGet))
+	out.Timeout = (*meta_v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.OnFailure = stash.HookFailurePolicy(in.OnFailure)
+	return nil
+}
+
+// Convert_v1alpha1_BackupHook_To_stash_BackupHook is an autogenerated conversion function.
+func Convert_v1alpha1_BackupHook_To_stash_BackupHook(in *BackupHook, out *stash.BackupHook, s conversion.Scope) error {
+	return autoConvert_v1alpha1_BackupHook_To_stash_BackupHook(in, out, s)
+}
+
+func autoConvert_stash_BackupHook_To_v1alpha1_BackupHook(in *stash.BackupHook, out *BackupHook, s conversion.Scope) error {
+	out.Exec = (*v1.ExecAction)(unsafe.Pointer(in.Exec))
+	out.Container = in.Container
+	out.HTTPGet = (*v1.HTTPGetAction)(unsafe.Pointer(in.HTTPGet))
+	out.Timeout = (*meta_v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.OnFailure = HookFailurePolicy(in.OnFailure)
+	return nil
+}
+
+// Convert_stash_BackupHook_To_v1alpha1_BackupHook is an autogenerated conversion function.
+func Convert_stash_BackupHook_To_v1alpha1_BackupHook(in *stash.BackupHook, out *BackupHook, s conversion.Scope) error {
+	return autoConvert_stash_BackupHook_To_v1alpha1_BackupHook(in, out, s)
+}
+
+func autoConvert_v1alpha1_BackupSession_To_stash_BackupSession(in *BackupSession, out *stash.BackupSession, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_BackupSessionSpec_To_stash_BackupSessionSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_BackupSessionStatus_To_stash_BackupSessionStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_BackupSession_To_stash_BackupSession is an autogenerated conversion function.
+func Convert_v1alpha1_BackupSession_To_stash_BackupSession(in *BackupSession, out *stash.BackupSession, s conversion.Scope) error {
+	return autoConvert_v1alpha1_BackupSession_To_stash_BackupSession(in, out, s)
+}
+
+func autoConvert_stash_BackupSession_To_v1alpha1_BackupSession(in *stash.BackupSession, out *BackupSession, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_stash_BackupSessionSpec_To_v1alpha1_BackupSessionSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_stash_BackupSessionStatus_To_v1alpha1_BackupSessionStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_stash_BackupSession_To_v1alpha1_BackupSession is an autogenerated conversion function.
+func Convert_stash_BackupSession_To_v1alpha1_BackupSession(in *stash.BackupSession, out *BackupSession, s conversion.Scope) error {
+	return autoConvert_stash_BackupSession_To_v1alpha1_BackupSession(in, out, s)
+}
+
+func autoConvert_v1alpha1_BackupSessionList_To_stash_BackupSessionList(in *BackupSessionList, out *stash.BackupSessionList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]stash.BackupSession)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1alpha1_BackupSessionList_To_stash_BackupSessionList is an autogenerated conversion function.
+func Convert_v1alpha1_BackupSessionList_To_stash_BackupSessionList(in *BackupSessionList, out *stash.BackupSessionList, s conversion.Scope) error {
+	return autoConvert_v1alpha1_BackupSessionList_To_stash_BackupSessionList(in, out, s)
+}
+
+func autoConvert_stash_BackupSessionList_To_v1alpha1_BackupSessionList(in *stash.BackupSessionList, out *BackupSessionList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]BackupSession)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_stash_BackupSessionList_To_v1alpha1_BackupSessionList is an autogenerated conversion function.
+func Convert_stash_BackupSessionList_To_v1alpha1_BackupSessionList(in *stash.BackupSessionList, out *BackupSessionList, s conversion.Scope) error {
+	return autoConvert_stash_BackupSessionList_To_v1alpha1_BackupSessionList(in, out, s)
+}
+
+func autoConvert_v1alpha1_BackupSessionSpec_To_stash_BackupSessionSpec(in *BackupSessionSpec, out *stash.BackupSessionSpec, s conversion.Scope) error {
+	out.Restic = in.Restic
+	return nil
+}
+
+// Convert_v1alpha1_BackupSessionSpec_To_stash_BackupSessionSpec is an autogenerated conversion function.
+func Convert_v1alpha1_BackupSessionSpec_To_stash_BackupSessionSpec(in *BackupSessionSpec, out *stash.BackupSessionSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_BackupSessionSpec_To_stash_BackupSessionSpec(in, out, s)
+}
+
+func autoConvert_stash_BackupSessionSpec_To_v1alpha1_BackupSessionSpec(in *stash.BackupSessionSpec, out *BackupSessionSpec, s conversion.Scope) error {
+	out.Restic = in.Restic
+	return nil
+}
+
+// Convert_stash_BackupSessionSpec_To_v1alpha1_BackupSessionSpec is an autogenerated conversion function.
+func Convert_stash_BackupSessionSpec_To_v1alpha1_BackupSessionSpec(in *stash.BackupSessionSpec, out *BackupSessionSpec, s conversion.Scope) error {
+	return autoConvert_stash_BackupSessionSpec_To_v1alpha1_BackupSessionSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_BackupSessionStatus_To_stash_BackupSessionStatus(in *BackupSessionStatus, out *stash.BackupSessionStatus, s conversion.Scope) error {
+	out.Phase = stash.BackupSessionPhase(in.Phase)
+	out.ExpectedHosts = *(*[]string)(unsafe.Pointer(&in.ExpectedHosts))
+	out.Hosts = *(*[]stash.HostBackupStatus)(unsafe.Pointer(&in.Hosts))
+	return nil
+}
+
+// Convert_v1alpha1_BackupSessionStatus_To_stash_BackupSessionStatus is an autogenerated conversion function.
+func Convert_v1alpha1_BackupSessionStatus_To_stash_BackupSessionStatus(in *BackupSessionStatus, out *stash.BackupSessionStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_BackupSessionStatus_To_stash_BackupSessionStatus(in, out, s)
+}
+
+func autoConvert_stash_BackupSessionStatus_To_v1alpha1_BackupSessionStatus(in *stash.BackupSessionStatus, out *BackupSessionStatus, s conversion.Scope) error {
+	out.Phase = BackupSessionPhase(in.Phase)
+	out.ExpectedHosts = *(*[]string)(unsafe.Pointer(&in.ExpectedHosts))
+	out.Hosts = *(*[]HostBackupStatus)(unsafe.Pointer(&in.Hosts))
+	return nil
+}
+
+// Convert_stash_BackupSessionStatus_To_v1alpha1_BackupSessionStatus is an autogenerated conversion function.
+func Convert_stash_BackupSessionStatus_To_v1alpha1_BackupSessionStatus(in *stash.BackupSessionStatus, out *BackupSessionStatus, s conversion.Scope) error {
+	return autoConvert_stash_BackupSessionStatus_To_v1alpha1_BackupSessionStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_BackupTemplate_To_stash_BackupTemplate(in *BackupTemplate, out *stash.BackupTemplate, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_BackupTemplateSpec_To_stash_BackupTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_BackupTemplate_To_stash_BackupTemplate is an autogenerated conversion function.
+func Convert_v1alpha1_BackupTemplate_To_stash_BackupTemplate(in *BackupTemplate, out *stash.BackupTemplate, s conversion.Scope) error {
+	return autoConvert_v1alpha1_BackupTemplate_To_stash_BackupTemplate(in, out, s)
+}
+
+func autoConvert_stash_BackupTemplate_To_v1alpha1_BackupTemplate(in *stash.BackupTemplate, out *BackupTemplate, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_stash_BackupTemplateSpec_To_v1alpha1_BackupTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_stash_BackupTemplate_To_v1alpha1_BackupTemplate is an autogenerated conversion function.
+func Convert_stash_BackupTemplate_To_v1alpha1_BackupTemplate(in *stash.BackupTemplate, out *BackupTemplate, s conversion.Scope) error {
+	return autoConvert_stash_BackupTemplate_To_v1alpha1_BackupTemplate(in, out, s)
+}
+
+func autoConvert_v1alpha1_BackupTemplateList_To_stash_BackupTemplateList(in *BackupTemplateList, out *stash.BackupTemplateList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]stash.BackupTemplate)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1alpha1_BackupTemplateList_To_stash_BackupTemplateList is an autogenerated conversion function.
+func Convert_v1alpha1_BackupTemplateList_To_stash_BackupTemplateList(in *BackupTemplateList, out *stash.BackupTemplateList, s conversion.Scope) error {
+	return autoConvert_v1alpha1_BackupTemplateList_To_stash_BackupTemplateList(in, out, s)
+}
+
+func autoConvert_stash_BackupTemplateList_To_v1alpha1_BackupTemplateList(in *stash.BackupTemplateList, out *BackupTemplateList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]BackupTemplate)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_stash_BackupTemplateList_To_v1alpha1_BackupTemplateList is an autogenerated conversion function.
+func Convert_stash_BackupTemplateList_To_v1alpha1_BackupTemplateList(in *stash.BackupTemplateList, out *BackupTemplateList, s conversion.Scope) error {
+	return autoConvert_stash_BackupTemplateList_To_v1alpha1_BackupTemplateList(in, out, s)
+}
+
+func autoConvert_v1alpha1_BackupTemplateSpec_To_stash_BackupTemplateSpec(in *BackupTemplateSpec, out *stash.BackupTemplateSpec, s conversion.Scope) error {
+	out.Backend = (*stash.Backend)(unsafe.Pointer(in.Backend))
+	out.Schedule = in.Schedule
+	out.Resources = in.Resources
+	out.RetentionPolicies = *(*[]stash.RetentionPolicy)(unsafe.Pointer(&in.RetentionPolicies))
+	return nil
+}
+
+// Convert_v1alpha1_BackupTemplateSpec_To_stash_BackupTemplateSpec is an autogenerated conversion function.
+func Convert_v1alpha1_BackupTemplateSpec_To_stash_BackupTemplateSpec(in *BackupTemplateSpec, out *stash.BackupTemplateSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_BackupTemplateSpec_To_stash_BackupTemplateSpec(in, out, s)
+}
+
+func autoConvert_stash_BackupTemplateSpec_To_v1alpha1_BackupTemplateSpec(in *stash.BackupTemplateSpec, out *BackupTemplateSpec, s conversion.Scope) error {
+	out.Backend = (*Backend)(unsafe.Pointer(in.Backend))
+	out.Schedule = in.Schedule
+	out.Resources = in.Resources
+	out.RetentionPolicies = *(*[]RetentionPolicy)(unsafe.Pointer(&in.RetentionPolicies))
+	return nil
+}
+
+// Convert_stash_BackupTemplateSpec_To_v1alpha1_BackupTemplateSpec is an autogenerated conversion function.
+func Convert_stash_BackupTemplateSpec_To_v1alpha1_BackupTemplateSpec(in *stash.BackupTemplateSpec, out *BackupTemplateSpec, s conversion.Scope) error {
+	return autoConvert_stash_BackupTemplateSpec_To_v1alpha1_BackupTemplateSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_BandwidthLimit_To_stash_BandwidthLimit(in *BandwidthLimit, out *stash.BandwidthLimit, s conversion.Scope) error {
+	out.Upload = in.Upload
+	out.Download = in.Download
+	return nil
+}
+
+// Convert_v1alpha1_BandwidthLimit_To_stash_BandwidthLimit is an autogenerated conversion function.
+func Convert_v1alpha1_BandwidthLimit_To_stash_BandwidthLimit(in *BandwidthLimit, out *stash.BandwidthLimit, s conversion.Scope) error {
+	return autoConvert_v1alpha1_BandwidthLimit_To_stash_BandwidthLimit(in, out, s)
+}
+
+func autoConvert_stash_BandwidthLimit_To_v1alpha1_BandwidthLimit(in *stash.BandwidthLimit, out *BandwidthLimit, s conversion.Scope) error {
+	out.Upload = in.Upload
+	out.Download = in.Download
+	return nil
+}
+
+// Convert_stash_BandwidthLimit_To_v1alpha1_BandwidthLimit is an autogenerated conversion function.
+func Convert_stash_BandwidthLimit_To_v1alpha1_BandwidthLimit(in *stash.BandwidthLimit, out *BandwidthLimit, s conversion.Scope) error {
+	return autoConvert_stash_BandwidthLimit_To_v1alpha1_BandwidthLimit(in, out, s)
+}
+
+func autoConvert_v1alpha1_CheckSpec_To_stash_CheckSpec(in *CheckSpec, out *stash.CheckSpec, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.ReadDataSubsets = in.ReadDataSubsets
+	return nil
+}
+
+// Convert_v1alpha1_CheckSpec_To_stash_CheckSpec is an autogenerated conversion function.
+func Convert_v1alpha1_CheckSpec_To_stash_CheckSpec(in *CheckSpec, out *stash.CheckSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CheckSpec_To_stash_CheckSpec(in, out, s)
+}
+
+func autoConvert_stash_CheckSpec_To_v1alpha1_CheckSpec(in *stash.CheckSpec, out *CheckSpec, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.ReadDataSubsets = in.ReadDataSubsets
+	return nil
+}
+
+// Convert_stash_CheckSpec_To_v1alpha1_CheckSpec is an autogenerated conversion function.
+func Convert_stash_CheckSpec_To_v1alpha1_CheckSpec(in *stash.CheckSpec, out *CheckSpec, s conversion.Scope) error {
+	return autoConvert_stash_CheckSpec_To_v1alpha1_CheckSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_ClusterRetentionPolicy_To_stash_ClusterRetentionPolicy(in *ClusterRetentionPolicy, out *stash.ClusterRetentionPolicy, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_RetentionPolicy_To_stash_RetentionPolicy(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_ClusterRetentionPolicy_To_stash_ClusterRetentionPolicy is an autogenerated conversion function.
+func Convert_v1alpha1_ClusterRetentionPolicy_To_stash_ClusterRetentionPolicy(in *ClusterRetentionPolicy, out *stash.ClusterRetentionPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ClusterRetentionPolicy_To_stash_ClusterRetentionPolicy(in, out, s)
+}
+
+func autoConvert_stash_ClusterRetentionPolicy_To_v1alpha1_ClusterRetentionPolicy(in *stash.ClusterRetentionPolicy, out *ClusterRetentionPolicy, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_stash_RetentionPolicy_To_v1alpha1_RetentionPolicy(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_stash_ClusterRetentionPolicy_To_v1alpha1_ClusterRetentionPolicy is an autogenerated conversion function.
+func Convert_stash_ClusterRetentionPolicy_To_v1alpha1_ClusterRetentionPolicy(in *stash.ClusterRetentionPolicy, out *ClusterRetentionPolicy, s conversion.Scope) error {
+	return autoConvert_stash_ClusterRetentionPolicy_To_v1alpha1_ClusterRetentionPolicy(in, out, s)
+}
+
+func autoConvert_v1alpha1_ClusterRetentionPolicyList_To_stash_ClusterRetentionPolicyList(in *ClusterRetentionPolicyList, out *stash.ClusterRetentionPolicyList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]stash.ClusterRetentionPolicy)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1alpha1_ClusterRetentionPolicyList_To_stash_ClusterRetentionPolicyList is an autogenerated conversion function.
+func Convert_v1alpha1_ClusterRetentionPolicyList_To_stash_ClusterRetentionPolicyList(in *ClusterRetentionPolicyList, out *stash.ClusterRetentionPolicyList, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ClusterRetentionPolicyList_To_stash_ClusterRetentionPolicyList(in, out, s)
+}
+
+func autoConvert_stash_ClusterRetentionPolicyList_To_v1alpha1_ClusterRetentionPolicyList(in *stash.ClusterRetentionPolicyList, out *ClusterRetentionPolicyList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]ClusterRetentionPolicy)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_stash_ClusterRetentionPolicyList_To_v1alpha1_ClusterRetentionPolicyList is an autogenerated conversion function.
+func Convert_stash_ClusterRetentionPolicyList_To_v1alpha1_ClusterRetentionPolicyList(in *stash.ClusterRetentionPolicyList, out *ClusterRetentionPolicyList, s conversion.Scope) error {
+	return autoConvert_stash_ClusterRetentionPolicyList_To_v1alpha1_ClusterRetentionPolicyList(in, out, s)
+}
+
 func autoConvert_v1alpha1_FileGroup_To_stash_FileGroup(in *FileGroup, out *stash.FileGroup, s conversion.Scope) error {
 	out.Path = in.Path
 	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
 	out.RetentionPolicyName = in.RetentionPolicyName
+	out.Stdin = (*stash.StdinSource)(unsafe.Pointer(in.Stdin))
+	out.Bandwidth = (*stash.BandwidthLimit)(unsafe.Pointer(in.Bandwidth))
+	out.Excludes = *(*[]string)(unsafe.Pointer(&in.Excludes))
+	out.ExcludeFile = in.ExcludeFile
+	out.ExcludeCaches = in.ExcludeCaches
+	out.OneFileSystem = in.OneFileSystem
+	out.Includes = *(*[]string)(unsafe.Pointer(&in.Includes))
 	return nil
 }
 
@@ -173,6 +526,13 @@ func autoConvert_stash_FileGroup_To_v1alpha1_FileGroup(in *stash.FileGroup, out
 	out.Path = in.Path
 	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
 	out.RetentionPolicyName = in.RetentionPolicyName
+	out.Stdin = (*StdinSource)(unsafe.Pointer(in.Stdin))
+	out.Bandwidth = (*BandwidthLimit)(unsafe.Pointer(in.Bandwidth))
+	out.Excludes = *(*[]string)(unsafe.Pointer(&in.Excludes))
+	out.ExcludeFile = in.ExcludeFile
+	out.ExcludeCaches = in.ExcludeCaches
+	out.OneFileSystem = in.OneFileSystem
+	out.Includes = *(*[]string)(unsafe.Pointer(&in.Includes))
 	return nil
 }
 
@@ -181,6 +541,44 @@ func Convert_stash_FileGroup_To_v1alpha1_FileGroup(in *stash.FileGroup, out *Fil
 	return autoConvert_stash_FileGroup_To_v1alpha1_FileGroup(in, out, s)
 }
 
+func autoConvert_v1alpha1_FileGroupBackupStatus_To_stash_FileGroupBackupStatus(in *FileGroupBackupStatus, out *stash.FileGroupBackupStatus, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Phase = stash.BackupSessionPhase(in.Phase)
+	out.Duration = in.Duration
+	out.Error = in.Error
+	out.SnapshotID = in.SnapshotID
+	out.DataAdded = in.DataAdded
+	out.TotalBytesProcessed = in.TotalBytesProcessed
+	out.FilesNew = in.FilesNew
+	out.FilesChanged = in.FilesChanged
+	out.FilesUnmodified = in.FilesUnmodified
+	return nil
+}
+
+// Convert_v1alpha1_FileGroupBackupStatus_To_stash_FileGroupBackupStatus is an autogenerated conversion function.
+func Convert_v1alpha1_FileGroupBackupStatus_To_stash_FileGroupBackupStatus(in *FileGroupBackupStatus, out *stash.FileGroupBackupStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FileGroupBackupStatus_To_stash_FileGroupBackupStatus(in, out, s)
+}
+
+func autoConvert_stash_FileGroupBackupStatus_To_v1alpha1_FileGroupBackupStatus(in *stash.FileGroupBackupStatus, out *FileGroupBackupStatus, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Phase = BackupSessionPhase(in.Phase)
+	out.Duration = in.Duration
+	out.Error = in.Error
+	out.SnapshotID = in.SnapshotID
+	out.DataAdded = in.DataAdded
+	out.TotalBytesProcessed = in.TotalBytesProcessed
+	out.FilesNew = in.FilesNew
+	out.FilesChanged = in.FilesChanged
+	out.FilesUnmodified = in.FilesUnmodified
+	return nil
+}
+
+// Convert_stash_FileGroupBackupStatus_To_v1alpha1_FileGroupBackupStatus is an autogenerated conversion function.
+func Convert_stash_FileGroupBackupStatus_To_v1alpha1_FileGroupBackupStatus(in *stash.FileGroupBackupStatus, out *FileGroupBackupStatus, s conversion.Scope) error {
+	return autoConvert_stash_FileGroupBackupStatus_To_v1alpha1_FileGroupBackupStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_GCSSpec_To_stash_GCSSpec(in *GCSSpec, out *stash.GCSSpec, s conversion.Scope) error {
 	out.Bucket = in.Bucket
 	out.Prefix = in.Prefix
@@ -203,6 +601,36 @@ func Convert_stash_GCSSpec_To_v1alpha1_GCSSpec(in *stash.GCSSpec, out *GCSSpec,
 	return autoConvert_stash_GCSSpec_To_v1alpha1_GCSSpec(in, out, s)
 }
 
+func autoConvert_v1alpha1_HostBackupStatus_To_stash_HostBackupStatus(in *HostBackupStatus, out *stash.HostBackupStatus, s conversion.Scope) error {
+	out.Hostname = in.Hostname
+	out.Phase = stash.BackupSessionPhase(in.Phase)
+	out.StartTime = (*meta_v1.Time)(unsafe.Pointer(in.StartTime))
+	out.EndTime = (*meta_v1.Time)(unsafe.Pointer(in.EndTime))
+	out.FileGroups = *(*[]stash.FileGroupBackupStatus)(unsafe.Pointer(&in.FileGroups))
+	out.Error = in.Error
+	return nil
+}
+
+// Convert_v1alpha1_HostBackupStatus_To_stash_HostBackupStatus is an autogenerated conversion function.
+func Convert_v1alpha1_HostBackupStatus_To_stash_HostBackupStatus(in *HostBackupStatus, out *stash.HostBackupStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_HostBackupStatus_To_stash_HostBackupStatus(in, out, s)
+}
+
+func autoConvert_stash_HostBackupStatus_To_v1alpha1_HostBackupStatus(in *stash.HostBackupStatus, out *HostBackupStatus, s conversion.Scope) error {
+	out.Hostname = in.Hostname
+	out.Phase = BackupSessionPhase(in.Phase)
+	out.StartTime = (*meta_v1.Time)(unsafe.Pointer(in.StartTime))
+	out.EndTime = (*meta_v1.Time)(unsafe.Pointer(in.EndTime))
+	out.FileGroups = *(*[]FileGroupBackupStatus)(unsafe.Pointer(&in.FileGroups))
+	out.Error = in.Error
+	return nil
+}
+
+// Convert_stash_HostBackupStatus_To_v1alpha1_HostBackupStatus is an autogenerated conversion function.
+func Convert_stash_HostBackupStatus_To_v1alpha1_HostBackupStatus(in *stash.HostBackupStatus, out *HostBackupStatus, s conversion.Scope) error {
+	return autoConvert_stash_HostBackupStatus_To_v1alpha1_HostBackupStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_LocalSpec_To_stash_LocalSpec(in *LocalSpec, out *stash.LocalSpec, s conversion.Scope) error {
 	out.VolumeSource = in.VolumeSource
 	out.MountPath = in.MountPath
@@ -251,6 +679,82 @@ func Convert_stash_LocalTypedReference_To_v1alpha1_LocalTypedReference(in *stash
 	return autoConvert_stash_LocalTypedReference_To_v1alpha1_LocalTypedReference(in, out, s)
 }
 
+func autoConvert_v1alpha1_MaintenanceSpec_To_stash_MaintenanceSpec(in *MaintenanceSpec, out *stash.MaintenanceSpec, s conversion.Scope) error {
+	out.Check = (*stash.CheckSpec)(unsafe.Pointer(in.Check))
+	out.Prune = (*stash.MaintenanceTaskSpec)(unsafe.Pointer(in.Prune))
+	out.Unlock = (*stash.MaintenanceTaskSpec)(unsafe.Pointer(in.Unlock))
+	out.RebuildIndex = (*stash.MaintenanceTaskSpec)(unsafe.Pointer(in.RebuildIndex))
+	return nil
+}
+
+// Convert_v1alpha1_MaintenanceSpec_To_stash_MaintenanceSpec is an autogenerated conversion function.
+func Convert_v1alpha1_MaintenanceSpec_To_stash_MaintenanceSpec(in *MaintenanceSpec, out *stash.MaintenanceSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MaintenanceSpec_To_stash_MaintenanceSpec(in, out, s)
+}
+
+func autoConvert_stash_MaintenanceSpec_To_v1alpha1_MaintenanceSpec(in *stash.MaintenanceSpec, out *MaintenanceSpec, s conversion.Scope) error {
+	out.Check = (*CheckSpec)(unsafe.Pointer(in.Check))
+	out.Prune = (*MaintenanceTaskSpec)(unsafe.Pointer(in.Prune))
+	out.Unlock = (*MaintenanceTaskSpec)(unsafe.Pointer(in.Unlock))
+	out.RebuildIndex = (*MaintenanceTaskSpec)(unsafe.Pointer(in.RebuildIndex))
+	return nil
+}
+
+// Convert_stash_MaintenanceSpec_To_v1alpha1_MaintenanceSpec is an autogenerated conversion function.
+func Convert_stash_MaintenanceSpec_To_v1alpha1_MaintenanceSpec(in *stash.MaintenanceSpec, out *MaintenanceSpec, s conversion.Scope) error {
+	return autoConvert_stash_MaintenanceSpec_To_v1alpha1_MaintenanceSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_MaintenanceStatus_To_stash_MaintenanceStatus(in *MaintenanceStatus, out *stash.MaintenanceStatus, s conversion.Scope) error {
+	out.Task = stash.MaintenanceTask(in.Task)
+	out.LastRunTime = (*meta_v1.Time)(unsafe.Pointer(in.LastRunTime))
+	out.LastSuccessfulRunTime = (*meta_v1.Time)(unsafe.Pointer(in.LastSuccessfulRunTime))
+	out.LastRunDuration = in.LastRunDuration
+	out.Error = in.Error
+	out.ReadDataSubset = in.ReadDataSubset
+	return nil
+}
+
+// Convert_v1alpha1_MaintenanceStatus_To_stash_MaintenanceStatus is an autogenerated conversion function.
+func Convert_v1alpha1_MaintenanceStatus_To_stash_MaintenanceStatus(in *MaintenanceStatus, out *stash.MaintenanceStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MaintenanceStatus_To_stash_MaintenanceStatus(in, out, s)
+}
+
+func autoConvert_stash_MaintenanceStatus_To_v1alpha1_MaintenanceStatus(in *stash.MaintenanceStatus, out *MaintenanceStatus, s conversion.Scope) error {
+	out.Task = MaintenanceTask(in.Task)
+	out.LastRunTime = (*meta_v1.Time)(unsafe.Pointer(in.LastRunTime))
+	out.LastSuccessfulRunTime = (*meta_v1.Time)(unsafe.Pointer(in.LastSuccessfulRunTime))
+	out.LastRunDuration = in.LastRunDuration
+	out.Error = in.Error
+	out.ReadDataSubset = in.ReadDataSubset
+	return nil
+}
+
+// Convert_stash_MaintenanceStatus_To_v1alpha1_MaintenanceStatus is an autogenerated conversion function.
+func Convert_stash_MaintenanceStatus_To_v1alpha1_MaintenanceStatus(in *stash.MaintenanceStatus, out *MaintenanceStatus, s conversion.Scope) error {
+	return autoConvert_stash_MaintenanceStatus_To_v1alpha1_MaintenanceStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_MaintenanceTaskSpec_To_stash_MaintenanceTaskSpec(in *MaintenanceTaskSpec, out *stash.MaintenanceTaskSpec, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	return nil
+}
+
+// Convert_v1alpha1_MaintenanceTaskSpec_To_stash_MaintenanceTaskSpec is an autogenerated conversion function.
+func Convert_v1alpha1_MaintenanceTaskSpec_To_stash_MaintenanceTaskSpec(in *MaintenanceTaskSpec, out *stash.MaintenanceTaskSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MaintenanceTaskSpec_To_stash_MaintenanceTaskSpec(in, out, s)
+}
+
+func autoConvert_stash_MaintenanceTaskSpec_To_v1alpha1_MaintenanceTaskSpec(in *stash.MaintenanceTaskSpec, out *MaintenanceTaskSpec, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	return nil
+}
+
+// Convert_stash_MaintenanceTaskSpec_To_v1alpha1_MaintenanceTaskSpec is an autogenerated conversion function.
+func Convert_stash_MaintenanceTaskSpec_To_v1alpha1_MaintenanceTaskSpec(in *stash.MaintenanceTaskSpec, out *MaintenanceTaskSpec, s conversion.Scope) error {
+	return autoConvert_stash_MaintenanceTaskSpec_To_v1alpha1_MaintenanceTaskSpec(in, out, s)
+}
+
 func autoConvert_v1alpha1_Recovery_To_stash_Recovery(in *Recovery, out *stash.Recovery, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha1_RecoverySpec_To_stash_RecoverySpec(&in.Spec, &out.Spec, s); err != nil {
@@ -316,6 +820,11 @@ func autoConvert_v1alpha1_RecoverySpec_To_stash_RecoverySpec(in *RecoverySpec, o
 	out.PodOrdinal = in.PodOrdinal
 	out.NodeName = in.NodeName
 	out.RecoveredVolumes = *(*[]stash.LocalSpec)(unsafe.Pointer(&in.RecoveredVolumes))
+	out.Snapshot = in.Snapshot
+	out.PointInTime = (*meta_v1.Time)(unsafe.Pointer(in.PointInTime))
+	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
+	out.Targets = *(*[]stash.RestoreTarget)(unsafe.Pointer(&in.Targets))
+	out.Bandwidth = (*stash.BandwidthLimit)(unsafe.Pointer(in.Bandwidth))
 	return nil
 }
 
@@ -335,6 +844,11 @@ func autoConvert_stash_RecoverySpec_To_v1alpha1_RecoverySpec(in *stash.RecoveryS
 	out.PodOrdinal = in.PodOrdinal
 	out.NodeName = in.NodeName
 	out.RecoveredVolumes = *(*[]LocalSpec)(unsafe.Pointer(&in.RecoveredVolumes))
+	out.Snapshot = in.Snapshot
+	out.PointInTime = (*meta_v1.Time)(unsafe.Pointer(in.PointInTime))
+	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
+	out.Targets = *(*[]RestoreTarget)(unsafe.Pointer(&in.Targets))
+	out.Bandwidth = (*BandwidthLimit)(unsafe.Pointer(in.Bandwidth))
 	return nil
 }
 
@@ -365,8 +879,135 @@ func Convert_stash_RecoveryStatus_To_v1alpha1_RecoveryStatus(in *stash.RecoveryS
 	return autoConvert_stash_RecoveryStatus_To_v1alpha1_RecoveryStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_Repository_To_stash_Repository(in *Repository, out *stash.Repository, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_RepositorySpec_To_stash_RepositorySpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_RepositoryStatus_To_stash_RepositoryStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_Repository_To_stash_Repository is an autogenerated conversion function.
+func Convert_v1alpha1_Repository_To_stash_Repository(in *Repository, out *stash.Repository, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Repository_To_stash_Repository(in, out, s)
+}
+
+func autoConvert_stash_Repository_To_v1alpha1_Repository(in *stash.Repository, out *Repository, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_stash_RepositorySpec_To_v1alpha1_RepositorySpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_stash_RepositoryStatus_To_v1alpha1_RepositoryStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_stash_Repository_To_v1alpha1_Repository is an autogenerated conversion function.
+func Convert_stash_Repository_To_v1alpha1_Repository(in *stash.Repository, out *Repository, s conversion.Scope) error {
+	return autoConvert_stash_Repository_To_v1alpha1_Repository(in, out, s)
+}
+
+func autoConvert_v1alpha1_RepositoryList_To_stash_RepositoryList(in *RepositoryList, out *stash.RepositoryList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]stash.Repository)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1alpha1_RepositoryList_To_stash_RepositoryList is an autogenerated conversion function.
+func Convert_v1alpha1_RepositoryList_To_stash_RepositoryList(in *RepositoryList, out *stash.RepositoryList, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RepositoryList_To_stash_RepositoryList(in, out, s)
+}
+
+func autoConvert_stash_RepositoryList_To_v1alpha1_RepositoryList(in *stash.RepositoryList, out *RepositoryList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]Repository)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_stash_RepositoryList_To_v1alpha1_RepositoryList is an autogenerated conversion function.
+func Convert_stash_RepositoryList_To_v1alpha1_RepositoryList(in *stash.RepositoryList, out *RepositoryList, s conversion.Scope) error {
+	return autoConvert_stash_RepositoryList_To_v1alpha1_RepositoryList(in, out, s)
+}
+
+func autoConvert_v1alpha1_RepositorySpec_To_stash_RepositorySpec(in *RepositorySpec, out *stash.RepositorySpec, s conversion.Scope) error {
+	if err := Convert_v1alpha1_Backend_To_stash_Backend(&in.Backend, &out.Backend, s); err != nil {
+		return err
+	}
+	out.Prefix = in.Prefix
+	if err := Convert_v1alpha1_LocalTypedReference_To_stash_LocalTypedReference(&in.Workload, &out.Workload, s); err != nil {
+		return err
+	}
+	out.Hostname = in.Hostname
+	out.Restic = in.Restic
+	return nil
+}
+
+// Convert_v1alpha1_RepositorySpec_To_stash_RepositorySpec is an autogenerated conversion function.
+func Convert_v1alpha1_RepositorySpec_To_stash_RepositorySpec(in *RepositorySpec, out *stash.RepositorySpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RepositorySpec_To_stash_RepositorySpec(in, out, s)
+}
+
+func autoConvert_stash_RepositorySpec_To_v1alpha1_RepositorySpec(in *stash.RepositorySpec, out *RepositorySpec, s conversion.Scope) error {
+	if err := Convert_stash_Backend_To_v1alpha1_Backend(&in.Backend, &out.Backend, s); err != nil {
+		return err
+	}
+	out.Prefix = in.Prefix
+	if err := Convert_stash_LocalTypedReference_To_v1alpha1_LocalTypedReference(&in.Workload, &out.Workload, s); err != nil {
+		return err
+	}
+	out.Hostname = in.Hostname
+	out.Restic = in.Restic
+	return nil
+}
+
+// Convert_stash_RepositorySpec_To_v1alpha1_RepositorySpec is an autogenerated conversion function.
+func Convert_stash_RepositorySpec_To_v1alpha1_RepositorySpec(in *stash.RepositorySpec, out *RepositorySpec, s conversion.Scope) error {
+	return autoConvert_stash_RepositorySpec_To_v1alpha1_RepositorySpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_RepositoryStatus_To_stash_RepositoryStatus(in *RepositoryStatus, out *stash.RepositoryStatus, s conversion.Scope) error {
+	out.FirstBackupTime = (*meta_v1.Time)(unsafe.Pointer(in.FirstBackupTime))
+	out.LastBackupTime = (*meta_v1.Time)(unsafe.Pointer(in.LastBackupTime))
+	out.LastSuccessfulBackupTime = (*meta_v1.Time)(unsafe.Pointer(in.LastSuccessfulBackupTime))
+	out.LastBackupDuration = in.LastBackupDuration
+	out.BackupCount = in.BackupCount
+	out.SnapshotCount = in.SnapshotCount
+	out.Size = in.Size
+	out.History = *(*[]stash.HostBackupStatus)(unsafe.Pointer(&in.History))
+	out.Maintenance = *(*[]stash.MaintenanceStatus)(unsafe.Pointer(&in.Maintenance))
+	return nil
+}
+
+// Convert_v1alpha1_RepositoryStatus_To_stash_RepositoryStatus is an autogenerated conversion function.
+func Convert_v1alpha1_RepositoryStatus_To_stash_RepositoryStatus(in *RepositoryStatus, out *stash.RepositoryStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RepositoryStatus_To_stash_RepositoryStatus(in, out, s)
+}
+
+func autoConvert_stash_RepositoryStatus_To_v1alpha1_RepositoryStatus(in *stash.RepositoryStatus, out *RepositoryStatus, s conversion.Scope) error {
+	out.FirstBackupTime = (*meta_v1.Time)(unsafe.Pointer(in.FirstBackupTime))
+	out.LastBackupTime = (*meta_v1.Time)(unsafe.Pointer(in.LastBackupTime))
+	out.LastSuccessfulBackupTime = (*meta_v1.Time)(unsafe.Pointer(in.LastSuccessfulBackupTime))
+	out.LastBackupDuration = in.LastBackupDuration
+	out.BackupCount = in.BackupCount
+	out.SnapshotCount = in.SnapshotCount
+	out.Size = in.Size
+	out.History = *(*[]HostBackupStatus)(unsafe.Pointer(&in.History))
+	out.Maintenance = *(*[]MaintenanceStatus)(unsafe.Pointer(&in.Maintenance))
+	return nil
+}
+
+// Convert_stash_RepositoryStatus_To_v1alpha1_RepositoryStatus is an autogenerated conversion function.
+func Convert_stash_RepositoryStatus_To_v1alpha1_RepositoryStatus(in *stash.RepositoryStatus, out *RepositoryStatus, s conversion.Scope) error {
+	return autoConvert_stash_RepositoryStatus_To_v1alpha1_RepositoryStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_RestServerSpec_To_stash_RestServerSpec(in *RestServerSpec, out *stash.RestServerSpec, s conversion.Scope) error {
 	out.URL = in.URL
+	out.AppendOnly = in.AppendOnly
 	return nil
 }
 
@@ -377,6 +1018,7 @@ func Convert_v1alpha1_RestServerSpec_To_stash_RestServerSpec(in *RestServerSpec,
 
 func autoConvert_stash_RestServerSpec_To_v1alpha1_RestServerSpec(in *stash.RestServerSpec, out *RestServerSpec, s conversion.Scope) error {
 	out.URL = in.URL
+	out.AppendOnly = in.AppendOnly
 	return nil
 }
 
@@ -446,10 +1088,20 @@ func autoConvert_v1alpha1_ResticSpec_To_stash_ResticSpec(in *ResticSpec, out *st
 		return err
 	}
 	out.Schedule = in.Schedule
+	out.Template = in.Template
+	out.ScheduleJitter = (*meta_v1.Duration)(unsafe.Pointer(in.ScheduleJitter))
 	out.VolumeMounts = *(*[]v1.VolumeMount)(unsafe.Pointer(&in.VolumeMounts))
 	out.Resources = in.Resources
 	out.RetentionPolicies = *(*[]stash.RetentionPolicy)(unsafe.Pointer(&in.RetentionPolicies))
 	out.Type = stash.BackupType(in.Type)
+	out.PreBackup = (*stash.BackupHook)(unsafe.Pointer(in.PreBackup))
+	out.PostBackup = (*stash.BackupHook)(unsafe.Pointer(in.PostBackup))
+	out.Maintenance = (*stash.MaintenanceSpec)(unsafe.Pointer(in.Maintenance))
+	out.Bandwidth = (*stash.BandwidthLimit)(unsafe.Pointer(in.Bandwidth))
+	out.FailurePolicy = stash.FailurePolicy(in.FailurePolicy)
+	out.Parallelism = in.Parallelism
+	out.Timeout = (*meta_v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.Retry = (*stash.RetryPolicy)(unsafe.Pointer(in.Retry))
 	return nil
 }
 
@@ -465,10 +1117,20 @@ func autoConvert_stash_ResticSpec_To_v1alpha1_ResticSpec(in *stash.ResticSpec, o
 		return err
 	}
 	out.Schedule = in.Schedule
+	out.Template = in.Template
+	out.ScheduleJitter = (*meta_v1.Duration)(unsafe.Pointer(in.ScheduleJitter))
 	out.VolumeMounts = *(*[]v1.VolumeMount)(unsafe.Pointer(&in.VolumeMounts))
 	out.Resources = in.Resources
 	out.RetentionPolicies = *(*[]RetentionPolicy)(unsafe.Pointer(&in.RetentionPolicies))
 	out.Type = BackupType(in.Type)
+	out.PreBackup = (*BackupHook)(unsafe.Pointer(in.PreBackup))
+	out.PostBackup = (*BackupHook)(unsafe.Pointer(in.PostBackup))
+	out.Maintenance = (*MaintenanceSpec)(unsafe.Pointer(in.Maintenance))
+	out.Bandwidth = (*BandwidthLimit)(unsafe.Pointer(in.Bandwidth))
+	out.FailurePolicy = FailurePolicy(in.FailurePolicy)
+	out.Parallelism = in.Parallelism
+	out.Timeout = (*meta_v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.Retry = (*RetryPolicy)(unsafe.Pointer(in.Retry))
 	return nil
 }
 
@@ -483,6 +1145,9 @@ func autoConvert_v1alpha1_ResticStatus_To_stash_ResticStatus(in *ResticStatus, o
 	out.LastSuccessfulBackupTime = (*meta_v1.Time)(unsafe.Pointer(in.LastSuccessfulBackupTime))
 	out.LastBackupDuration = in.LastBackupDuration
 	out.BackupCount = in.BackupCount
+	out.Template = (*stash.BackupTemplateSpec)(unsafe.Pointer(in.Template))
+	out.ClusterRetentionPolicies = *(*[]stash.RetentionPolicy)(unsafe.Pointer(&in.ClusterRetentionPolicies))
+	out.TemplateError = in.TemplateError
 	return nil
 }
 
@@ -497,6 +1162,9 @@ func autoConvert_stash_ResticStatus_To_v1alpha1_ResticStatus(in *stash.ResticSta
 	out.LastSuccessfulBackupTime = (*meta_v1.Time)(unsafe.Pointer(in.LastSuccessfulBackupTime))
 	out.LastBackupDuration = in.LastBackupDuration
 	out.BackupCount = in.BackupCount
+	out.Template = (*BackupTemplateSpec)(unsafe.Pointer(in.Template))
+	out.ClusterRetentionPolicies = *(*[]RetentionPolicy)(unsafe.Pointer(&in.ClusterRetentionPolicies))
+	out.TemplateError = in.TemplateError
 	return nil
 }
 
@@ -529,6 +1197,34 @@ func Convert_stash_RestoreStats_To_v1alpha1_RestoreStats(in *stash.RestoreStats,
 	return autoConvert_stash_RestoreStats_To_v1alpha1_RestoreStats(in, out, s)
 }
 
+func autoConvert_v1alpha1_RestoreTarget_To_stash_RestoreTarget(in *RestoreTarget, out *stash.RestoreTarget, s conversion.Scope) error {
+	out.Path = in.Path
+	out.TargetPath = in.TargetPath
+	out.Include = *(*[]string)(unsafe.Pointer(&in.Include))
+	out.Exclude = *(*[]string)(unsafe.Pointer(&in.Exclude))
+	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	return nil
+}
+
+// Convert_v1alpha1_RestoreTarget_To_stash_RestoreTarget is an autogenerated conversion function.
+func Convert_v1alpha1_RestoreTarget_To_stash_RestoreTarget(in *RestoreTarget, out *stash.RestoreTarget, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RestoreTarget_To_stash_RestoreTarget(in, out, s)
+}
+
+func autoConvert_stash_RestoreTarget_To_v1alpha1_RestoreTarget(in *stash.RestoreTarget, out *RestoreTarget, s conversion.Scope) error {
+	out.Path = in.Path
+	out.TargetPath = in.TargetPath
+	out.Include = *(*[]string)(unsafe.Pointer(&in.Include))
+	out.Exclude = *(*[]string)(unsafe.Pointer(&in.Exclude))
+	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	return nil
+}
+
+// Convert_stash_RestoreTarget_To_v1alpha1_RestoreTarget is an autogenerated conversion function.
+func Convert_stash_RestoreTarget_To_v1alpha1_RestoreTarget(in *stash.RestoreTarget, out *RestoreTarget, s conversion.Scope) error {
+	return autoConvert_stash_RestoreTarget_To_v1alpha1_RestoreTarget(in, out, s)
+}
+
 func autoConvert_v1alpha1_RetentionPolicy_To_stash_RetentionPolicy(in *RetentionPolicy, out *stash.RetentionPolicy, s conversion.Scope) error {
 	out.Name = in.Name
 	out.KeepLast = in.KeepLast
@@ -538,6 +1234,8 @@ func autoConvert_v1alpha1_RetentionPolicy_To_stash_RetentionPolicy(in *Retention
 	out.KeepMonthly = in.KeepMonthly
 	out.KeepYearly = in.KeepYearly
 	out.KeepTags = *(*[]string)(unsafe.Pointer(&in.KeepTags))
+	out.KeepWithin = in.KeepWithin
+	out.GroupBy = *(*[]stash.SnapshotGroupBy)(unsafe.Pointer(&in.GroupBy))
 	out.Prune = in.Prune
 	out.DryRun = in.DryRun
 	return nil
@@ -557,6 +1255,8 @@ func autoConvert_stash_RetentionPolicy_To_v1alpha1_RetentionPolicy(in *stash.Ret
 	out.KeepMonthly = in.KeepMonthly
 	out.KeepYearly = in.KeepYearly
 	out.KeepTags = *(*[]string)(unsafe.Pointer(&in.KeepTags))
+	out.KeepWithin = in.KeepWithin
+	out.GroupBy = *(*[]SnapshotGroupBy)(unsafe.Pointer(&in.GroupBy))
 	out.Prune = in.Prune
 	out.DryRun = in.DryRun
 	return nil
@@ -567,10 +1267,35 @@ func Convert_stash_RetentionPolicy_To_v1alpha1_RetentionPolicy(in *stash.Retenti
 	return autoConvert_stash_RetentionPolicy_To_v1alpha1_RetentionPolicy(in, out, s)
 }
 
+func autoConvert_v1alpha1_RetryPolicy_To_stash_RetryPolicy(in *RetryPolicy, out *stash.RetryPolicy, s conversion.Scope) error {
+	out.MaxAttempts = in.MaxAttempts
+	out.Backoff = (*meta_v1.Duration)(unsafe.Pointer(in.Backoff))
+	return nil
+}
+
+// Convert_v1alpha1_RetryPolicy_To_stash_RetryPolicy is an autogenerated conversion function.
+func Convert_v1alpha1_RetryPolicy_To_stash_RetryPolicy(in *RetryPolicy, out *stash.RetryPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RetryPolicy_To_stash_RetryPolicy(in, out, s)
+}
+
+func autoConvert_stash_RetryPolicy_To_v1alpha1_RetryPolicy(in *stash.RetryPolicy, out *RetryPolicy, s conversion.Scope) error {
+	out.MaxAttempts = in.MaxAttempts
+	out.Backoff = (*meta_v1.Duration)(unsafe.Pointer(in.Backoff))
+	return nil
+}
+
+// Convert_stash_RetryPolicy_To_v1alpha1_RetryPolicy is an autogenerated conversion function.
+func Convert_stash_RetryPolicy_To_v1alpha1_RetryPolicy(in *stash.RetryPolicy, out *RetryPolicy, s conversion.Scope) error {
+	return autoConvert_stash_RetryPolicy_To_v1alpha1_RetryPolicy(in, out, s)
+}
+
 func autoConvert_v1alpha1_S3Spec_To_stash_S3Spec(in *S3Spec, out *stash.S3Spec, s conversion.Scope) error {
 	out.Endpoint = in.Endpoint
 	out.Bucket = in.Bucket
 	out.Prefix = in.Prefix
+	out.Region = in.Region
+	out.ForcePathStyle = in.ForcePathStyle
+	out.StorageClass = in.StorageClass
 	return nil
 }
 
@@ -583,6 +1308,9 @@ func autoConvert_stash_S3Spec_To_v1alpha1_S3Spec(in *stash.S3Spec, out *S3Spec,
 	out.Endpoint = in.Endpoint
 	out.Bucket = in.Bucket
 	out.Prefix = in.Prefix
+	out.Region = in.Region
+	out.ForcePathStyle = in.ForcePathStyle
+	out.StorageClass = in.StorageClass
 	return nil
 }
 
@@ -591,6 +1319,54 @@ func Convert_stash_S3Spec_To_v1alpha1_S3Spec(in *stash.S3Spec, out *S3Spec, s co
 	return autoConvert_stash_S3Spec_To_v1alpha1_S3Spec(in, out, s)
 }
 
+func autoConvert_v1alpha1_SFTPSpec_To_stash_SFTPSpec(in *SFTPSpec, out *stash.SFTPSpec, s conversion.Scope) error {
+	out.Host = in.Host
+	out.Port = in.Port
+	out.User = in.User
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_v1alpha1_SFTPSpec_To_stash_SFTPSpec is an autogenerated conversion function.
+func Convert_v1alpha1_SFTPSpec_To_stash_SFTPSpec(in *SFTPSpec, out *stash.SFTPSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SFTPSpec_To_stash_SFTPSpec(in, out, s)
+}
+
+func autoConvert_stash_SFTPSpec_To_v1alpha1_SFTPSpec(in *stash.SFTPSpec, out *SFTPSpec, s conversion.Scope) error {
+	out.Host = in.Host
+	out.Port = in.Port
+	out.User = in.User
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_stash_SFTPSpec_To_v1alpha1_SFTPSpec is an autogenerated conversion function.
+func Convert_stash_SFTPSpec_To_v1alpha1_SFTPSpec(in *stash.SFTPSpec, out *SFTPSpec, s conversion.Scope) error {
+	return autoConvert_stash_SFTPSpec_To_v1alpha1_SFTPSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_StdinSource_To_stash_StdinSource(in *StdinSource, out *stash.StdinSource, s conversion.Scope) error {
+	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	out.Container = in.Container
+	return nil
+}
+
+// Convert_v1alpha1_StdinSource_To_stash_StdinSource is an autogenerated conversion function.
+func Convert_v1alpha1_StdinSource_To_stash_StdinSource(in *StdinSource, out *stash.StdinSource, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StdinSource_To_stash_StdinSource(in, out, s)
+}
+
+func autoConvert_stash_StdinSource_To_v1alpha1_StdinSource(in *stash.StdinSource, out *StdinSource, s conversion.Scope) error {
+	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	out.Container = in.Container
+	return nil
+}
+
+// Convert_stash_StdinSource_To_v1alpha1_StdinSource is an autogenerated conversion function.
+func Convert_stash_StdinSource_To_v1alpha1_StdinSource(in *stash.StdinSource, out *StdinSource, s conversion.Scope) error {
+	return autoConvert_stash_StdinSource_To_v1alpha1_StdinSource(in, out, s)
+}
+
 func autoConvert_v1alpha1_SwiftSpec_To_stash_SwiftSpec(in *SwiftSpec, out *stash.SwiftSpec, s conversion.Scope) error {
 	out.Container = in.Container
 	out.Prefix = in.Prefix
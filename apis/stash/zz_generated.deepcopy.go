@@ -51,14 +51,70 @@ func RegisterDeepCopies(scheme *runtime.Scheme) error {
 			in.(*Backend).DeepCopyInto(out.(*Backend))
 			return nil
 		}, InType: reflect.TypeOf(&Backend{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*BackupHook).DeepCopyInto(out.(*BackupHook))
+			return nil
+		}, InType: reflect.TypeOf(&BackupHook{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*BackupSession).DeepCopyInto(out.(*BackupSession))
+			return nil
+		}, InType: reflect.TypeOf(&BackupSession{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*BackupSessionList).DeepCopyInto(out.(*BackupSessionList))
+			return nil
+		}, InType: reflect.TypeOf(&BackupSessionList{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*BackupSessionSpec).DeepCopyInto(out.(*BackupSessionSpec))
+			return nil
+		}, InType: reflect.TypeOf(&BackupSessionSpec{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*BackupSessionStatus).DeepCopyInto(out.(*BackupSessionStatus))
+			return nil
+		}, InType: reflect.TypeOf(&BackupSessionStatus{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*BackupTemplate).DeepCopyInto(out.(*BackupTemplate))
+			return nil
+		}, InType: reflect.TypeOf(&BackupTemplate{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*BackupTemplateList).DeepCopyInto(out.(*BackupTemplateList))
+			return nil
+		}, InType: reflect.TypeOf(&BackupTemplateList{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*BackupTemplateSpec).DeepCopyInto(out.(*BackupTemplateSpec))
+			return nil
+		}, InType: reflect.TypeOf(&BackupTemplateSpec{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*BandwidthLimit).DeepCopyInto(out.(*BandwidthLimit))
+			return nil
+		}, InType: reflect.TypeOf(&BandwidthLimit{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*CheckSpec).DeepCopyInto(out.(*CheckSpec))
+			return nil
+		}, InType: reflect.TypeOf(&CheckSpec{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*ClusterRetentionPolicy).DeepCopyInto(out.(*ClusterRetentionPolicy))
+			return nil
+		}, InType: reflect.TypeOf(&ClusterRetentionPolicy{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*ClusterRetentionPolicyList).DeepCopyInto(out.(*ClusterRetentionPolicyList))
+			return nil
+		}, InType: reflect.TypeOf(&ClusterRetentionPolicyList{})},
 		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
 			in.(*FileGroup).DeepCopyInto(out.(*FileGroup))
 			return nil
 		}, InType: reflect.TypeOf(&FileGroup{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*FileGroupBackupStatus).DeepCopyInto(out.(*FileGroupBackupStatus))
+			return nil
+		}, InType: reflect.TypeOf(&FileGroupBackupStatus{})},
 		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
 			in.(*GCSSpec).DeepCopyInto(out.(*GCSSpec))
 			return nil
 		}, InType: reflect.TypeOf(&GCSSpec{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*HostBackupStatus).DeepCopyInto(out.(*HostBackupStatus))
+			return nil
+		}, InType: reflect.TypeOf(&HostBackupStatus{})},
 		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
 			in.(*LocalSpec).DeepCopyInto(out.(*LocalSpec))
 			return nil
@@ -67,6 +123,18 @@ func RegisterDeepCopies(scheme *runtime.Scheme) error {
 			in.(*LocalTypedReference).DeepCopyInto(out.(*LocalTypedReference))
 			return nil
 		}, InType: reflect.TypeOf(&LocalTypedReference{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*MaintenanceSpec).DeepCopyInto(out.(*MaintenanceSpec))
+			return nil
+		}, InType: reflect.TypeOf(&MaintenanceSpec{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*MaintenanceStatus).DeepCopyInto(out.(*MaintenanceStatus))
+			return nil
+		}, InType: reflect.TypeOf(&MaintenanceStatus{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*MaintenanceTaskSpec).DeepCopyInto(out.(*MaintenanceTaskSpec))
+			return nil
+		}, InType: reflect.TypeOf(&MaintenanceTaskSpec{})},
 		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
 			in.(*Recovery).DeepCopyInto(out.(*Recovery))
 			return nil
@@ -83,6 +151,22 @@ func RegisterDeepCopies(scheme *runtime.Scheme) error {
 			in.(*RecoveryStatus).DeepCopyInto(out.(*RecoveryStatus))
 			return nil
 		}, InType: reflect.TypeOf(&RecoveryStatus{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*Repository).DeepCopyInto(out.(*Repository))
+			return nil
+		}, InType: reflect.TypeOf(&Repository{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*RepositoryList).DeepCopyInto(out.(*RepositoryList))
+			return nil
+		}, InType: reflect.TypeOf(&RepositoryList{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*RepositorySpec).DeepCopyInto(out.(*RepositorySpec))
+			return nil
+		}, InType: reflect.TypeOf(&RepositorySpec{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*RepositoryStatus).DeepCopyInto(out.(*RepositoryStatus))
+			return nil
+		}, InType: reflect.TypeOf(&RepositoryStatus{})},
 		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
 			in.(*RestServerSpec).DeepCopyInto(out.(*RestServerSpec))
 			return nil
@@ -107,14 +191,30 @@ func RegisterDeepCopies(scheme *runtime.Scheme) error {
 			in.(*RestoreStats).DeepCopyInto(out.(*RestoreStats))
 			return nil
 		}, InType: reflect.TypeOf(&RestoreStats{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*RestoreTarget).DeepCopyInto(out.(*RestoreTarget))
+			return nil
+		}, InType: reflect.TypeOf(&RestoreTarget{})},
 		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
 			in.(*RetentionPolicy).DeepCopyInto(out.(*RetentionPolicy))
 			return nil
 		}, InType: reflect.TypeOf(&RetentionPolicy{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*RetryPolicy).DeepCopyInto(out.(*RetryPolicy))
+			return nil
+		}, InType: reflect.TypeOf(&RetryPolicy{})},
 		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
 			in.(*S3Spec).DeepCopyInto(out.(*S3Spec))
 			return nil
 		}, InType: reflect.TypeOf(&S3Spec{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*SFTPSpec).DeepCopyInto(out.(*SFTPSpec))
+			return nil
+		}, InType: reflect.TypeOf(&SFTPSpec{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*StdinSource).DeepCopyInto(out.(*StdinSource))
+			return nil
+		}, InType: reflect.TypeOf(&StdinSource{})},
 		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
 			in.(*SwiftSpec).DeepCopyInto(out.(*SwiftSpec))
 			return nil
@@ -211,6 +311,24 @@ func (in *Backend) DeepCopyInto(out *Backend) {
 			**out = **in
 		}
 	}
+	if in.Rest != nil {
+		in, out := &in.Rest, &out.Rest
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(RestServerSpec)
+			**out = **in
+		}
+	}
+	if in.SFTP != nil {
+		in, out := &in.SFTP, &out.SFTP
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(SFTPSpec)
+			**out = **in
+		}
+	}
 	return
 }
 
@@ -225,97 +343,176 @@ func (in *Backend) DeepCopy() *Backend {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FileGroup) DeepCopyInto(out *FileGroup) {
+func (in *BackupHook) DeepCopyInto(out *BackupHook) {
 	*out = *in
-	if in.Tags != nil {
-		in, out := &in.Tags, &out.Tags
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.ExecAction)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.HTTPGet != nil {
+		in, out := &in.HTTPGet, &out.HTTPGet
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.HTTPGetAction)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.Duration)
+			**out = **in
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileGroup.
-func (in *FileGroup) DeepCopy() *FileGroup {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupHook.
+func (in *BackupHook) DeepCopy() *BackupHook {
 	if in == nil {
 		return nil
 	}
-	out := new(FileGroup)
+	out := new(BackupHook)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCSSpec) DeepCopyInto(out *GCSSpec) {
+func (in *BackupSession) DeepCopyInto(out *BackupSession) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCSSpec.
-func (in *GCSSpec) DeepCopy() *GCSSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSession.
+func (in *BackupSession) DeepCopy() *BackupSession {
 	if in == nil {
 		return nil
 	}
-	out := new(GCSSpec)
+	out := new(BackupSession)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackupSession) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	} else {
+		return nil
+	}
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocalSpec) DeepCopyInto(out *LocalSpec) {
+func (in *BackupSessionList) DeepCopyInto(out *BackupSessionList) {
 	*out = *in
-	in.VolumeSource.DeepCopyInto(&out.VolumeSource)
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BackupSession, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalSpec.
-func (in *LocalSpec) DeepCopy() *LocalSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSessionList.
+func (in *BackupSessionList) DeepCopy() *BackupSessionList {
 	if in == nil {
 		return nil
 	}
-	out := new(LocalSpec)
+	out := new(BackupSessionList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackupSessionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	} else {
+		return nil
+	}
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocalTypedReference) DeepCopyInto(out *LocalTypedReference) {
+func (in *BackupSessionSpec) DeepCopyInto(out *BackupSessionSpec) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalTypedReference.
-func (in *LocalTypedReference) DeepCopy() *LocalTypedReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSessionSpec.
+func (in *BackupSessionSpec) DeepCopy() *BackupSessionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LocalTypedReference)
+	out := new(BackupSessionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Recovery) DeepCopyInto(out *Recovery) {
+func (in *BackupSessionStatus) DeepCopyInto(out *BackupSessionStatus) {
+	*out = *in
+	if in.ExpectedHosts != nil {
+		in, out := &in.ExpectedHosts, &out.ExpectedHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]HostBackupStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSessionStatus.
+func (in *BackupSessionStatus) DeepCopy() *BackupSessionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupSessionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupTemplate) DeepCopyInto(out *BackupTemplate) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Recovery.
-func (in *Recovery) DeepCopy() *Recovery {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupTemplate.
+func (in *BackupTemplate) DeepCopy() *BackupTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(Recovery)
+	out := new(BackupTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Recovery) DeepCopyObject() runtime.Object {
+func (in *BackupTemplate) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	} else {
@@ -324,13 +521,13 @@ func (in *Recovery) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RecoveryList) DeepCopyInto(out *RecoveryList) {
+func (in *BackupTemplateList) DeepCopyInto(out *BackupTemplateList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Recovery, len(*in))
+		*out = make([]BackupTemplate, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -338,18 +535,18 @@ func (in *RecoveryList) DeepCopyInto(out *RecoveryList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecoveryList.
-func (in *RecoveryList) DeepCopy() *RecoveryList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupTemplateList.
+func (in *BackupTemplateList) DeepCopy() *BackupTemplateList {
 	if in == nil {
 		return nil
 	}
-	out := new(RecoveryList)
+	out := new(BackupTemplateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RecoveryList) DeepCopyObject() runtime.Object {
+func (in *BackupTemplateList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	} else {
@@ -358,18 +555,21 @@ func (in *RecoveryList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RecoverySpec) DeepCopyInto(out *RecoverySpec) {
+func (in *BackupTemplateSpec) DeepCopyInto(out *BackupTemplateSpec) {
 	*out = *in
-	in.Backend.DeepCopyInto(&out.Backend)
-	if in.Paths != nil {
-		in, out := &in.Paths, &out.Paths
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Backend != nil {
+		in, out := &in.Backend, &out.Backend
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(Backend)
+			(*in).DeepCopyInto(*out)
+		}
 	}
-	out.Workload = in.Workload
-	if in.RecoveredVolumes != nil {
-		in, out := &in.RecoveredVolumes, &out.RecoveredVolumes
-		*out = make([]LocalSpec, len(*in))
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.RetentionPolicies != nil {
+		in, out := &in.RetentionPolicies, &out.RetentionPolicies
+		*out = make([]RetentionPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -377,75 +577,69 @@ func (in *RecoverySpec) DeepCopyInto(out *RecoverySpec) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecoverySpec.
-func (in *RecoverySpec) DeepCopy() *RecoverySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupTemplateSpec.
+func (in *BackupTemplateSpec) DeepCopy() *BackupTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RecoverySpec)
+	out := new(BackupTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RecoveryStatus) DeepCopyInto(out *RecoveryStatus) {
+func (in *BandwidthLimit) DeepCopyInto(out *BandwidthLimit) {
 	*out = *in
-	if in.Stats != nil {
-		in, out := &in.Stats, &out.Stats
-		*out = make([]RestoreStats, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecoveryStatus.
-func (in *RecoveryStatus) DeepCopy() *RecoveryStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BandwidthLimit.
+func (in *BandwidthLimit) DeepCopy() *BandwidthLimit {
 	if in == nil {
 		return nil
 	}
-	out := new(RecoveryStatus)
+	out := new(BandwidthLimit)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RestServerSpec) DeepCopyInto(out *RestServerSpec) {
+func (in *CheckSpec) DeepCopyInto(out *CheckSpec) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestServerSpec.
-func (in *RestServerSpec) DeepCopy() *RestServerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckSpec.
+func (in *CheckSpec) DeepCopy() *CheckSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RestServerSpec)
+	out := new(CheckSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Restic) DeepCopyInto(out *Restic) {
+func (in *ClusterRetentionPolicy) DeepCopyInto(out *ClusterRetentionPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Restic.
-func (in *Restic) DeepCopy() *Restic {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRetentionPolicy.
+func (in *ClusterRetentionPolicy) DeepCopy() *ClusterRetentionPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(Restic)
+	out := new(ClusterRetentionPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Restic) DeepCopyObject() runtime.Object {
+func (in *ClusterRetentionPolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	} else {
@@ -454,13 +648,13 @@ func (in *Restic) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResticList) DeepCopyInto(out *ResticList) {
+func (in *ClusterRetentionPolicyList) DeepCopyInto(out *ClusterRetentionPolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Restic, len(*in))
+		*out = make([]ClusterRetentionPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -468,18 +662,18 @@ func (in *ResticList) DeepCopyInto(out *ResticList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticList.
-func (in *ResticList) DeepCopy() *ResticList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRetentionPolicyList.
+func (in *ClusterRetentionPolicyList) DeepCopy() *ClusterRetentionPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(ResticList)
+	out := new(ClusterRetentionPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ResticList) DeepCopyObject() runtime.Object {
+func (in *ClusterRetentionPolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	} else {
@@ -488,53 +682,732 @@ func (in *ResticList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResticSpec) DeepCopyInto(out *ResticSpec) {
+func (in *FileGroup) DeepCopyInto(out *FileGroup) {
 	*out = *in
-	in.Selector.DeepCopyInto(&out.Selector)
-	if in.FileGroups != nil {
-		in, out := &in.FileGroups, &out.FileGroups
-		*out = make([]FileGroup, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	in.Backend.DeepCopyInto(&out.Backend)
-	if in.VolumeMounts != nil {
-		in, out := &in.VolumeMounts, &out.VolumeMounts
-		*out = make([]v1.VolumeMount, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Stdin != nil {
+		in, out := &in.Stdin, &out.Stdin
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(StdinSource)
+			(*in).DeepCopyInto(*out)
 		}
 	}
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.RetentionPolicies != nil {
-		in, out := &in.RetentionPolicies, &out.RetentionPolicies
-		*out = make([]RetentionPolicy, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Bandwidth != nil {
+		in, out := &in.Bandwidth, &out.Bandwidth
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(BandwidthLimit)
+			**out = **in
 		}
 	}
+	if in.Excludes != nil {
+		in, out := &in.Excludes, &out.Excludes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Includes != nil {
+		in, out := &in.Includes, &out.Includes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticSpec.
-func (in *ResticSpec) DeepCopy() *ResticSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileGroup.
+func (in *FileGroup) DeepCopy() *FileGroup {
 	if in == nil {
 		return nil
 	}
-	out := new(ResticSpec)
+	out := new(FileGroup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResticStatus) DeepCopyInto(out *ResticStatus) {
+func (in *FileGroupBackupStatus) DeepCopyInto(out *FileGroupBackupStatus) {
 	*out = *in
-	if in.FirstBackupTime != nil {
-		in, out := &in.FirstBackupTime, &out.FirstBackupTime
-		if *in == nil {
-			*out = nil
-		} else {
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileGroupBackupStatus.
+func (in *FileGroupBackupStatus) DeepCopy() *FileGroupBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FileGroupBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCSSpec) DeepCopyInto(out *GCSSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCSSpec.
+func (in *GCSSpec) DeepCopy() *GCSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostBackupStatus) DeepCopyInto(out *HostBackupStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.Time)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.Time)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.FileGroups != nil {
+		in, out := &in.FileGroups, &out.FileGroups
+		*out = make([]FileGroupBackupStatus, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostBackupStatus.
+func (in *HostBackupStatus) DeepCopy() *HostBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HostBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalSpec) DeepCopyInto(out *LocalSpec) {
+	*out = *in
+	in.VolumeSource.DeepCopyInto(&out.VolumeSource)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalSpec.
+func (in *LocalSpec) DeepCopy() *LocalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalTypedReference) DeepCopyInto(out *LocalTypedReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalTypedReference.
+func (in *LocalTypedReference) DeepCopy() *LocalTypedReference {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalTypedReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceSpec) DeepCopyInto(out *MaintenanceSpec) {
+	*out = *in
+	if in.Check != nil {
+		in, out := &in.Check, &out.Check
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(CheckSpec)
+			**out = **in
+		}
+	}
+	if in.Prune != nil {
+		in, out := &in.Prune, &out.Prune
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(MaintenanceTaskSpec)
+			**out = **in
+		}
+	}
+	if in.Unlock != nil {
+		in, out := &in.Unlock, &out.Unlock
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(MaintenanceTaskSpec)
+			**out = **in
+		}
+	}
+	if in.RebuildIndex != nil {
+		in, out := &in.RebuildIndex, &out.RebuildIndex
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(MaintenanceTaskSpec)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceSpec.
+func (in *MaintenanceSpec) DeepCopy() *MaintenanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceStatus) DeepCopyInto(out *MaintenanceStatus) {
+	*out = *in
+	if in.LastRunTime != nil {
+		in, out := &in.LastRunTime, &out.LastRunTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.Time)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.LastSuccessfulRunTime != nil {
+		in, out := &in.LastSuccessfulRunTime, &out.LastSuccessfulRunTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.Time)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceStatus.
+func (in *MaintenanceStatus) DeepCopy() *MaintenanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceTaskSpec) DeepCopyInto(out *MaintenanceTaskSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceTaskSpec.
+func (in *MaintenanceTaskSpec) DeepCopy() *MaintenanceTaskSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceTaskSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Recovery) DeepCopyInto(out *Recovery) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Recovery.
+func (in *Recovery) DeepCopy() *Recovery {
+	if in == nil {
+		return nil
+	}
+	out := new(Recovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Recovery) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	} else {
+		return nil
+	}
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecoveryList) DeepCopyInto(out *RecoveryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Recovery, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecoveryList.
+func (in *RecoveryList) DeepCopy() *RecoveryList {
+	if in == nil {
+		return nil
+	}
+	out := new(RecoveryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RecoveryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	} else {
+		return nil
+	}
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecoverySpec) DeepCopyInto(out *RecoverySpec) {
+	*out = *in
+	in.Backend.DeepCopyInto(&out.Backend)
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Workload = in.Workload
+	if in.RecoveredVolumes != nil {
+		in, out := &in.RecoveredVolumes, &out.RecoveredVolumes
+		*out = make([]LocalSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PointInTime != nil {
+		in, out := &in.PointInTime, &out.PointInTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.Time)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]RestoreTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Bandwidth != nil {
+		in, out := &in.Bandwidth, &out.Bandwidth
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(BandwidthLimit)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecoverySpec.
+func (in *RecoverySpec) DeepCopy() *RecoverySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RecoverySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecoveryStatus) DeepCopyInto(out *RecoveryStatus) {
+	*out = *in
+	if in.Stats != nil {
+		in, out := &in.Stats, &out.Stats
+		*out = make([]RestoreStats, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecoveryStatus.
+func (in *RecoveryStatus) DeepCopy() *RecoveryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RecoveryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Repository) DeepCopyInto(out *Repository) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Repository.
+func (in *Repository) DeepCopy() *Repository {
+	if in == nil {
+		return nil
+	}
+	out := new(Repository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Repository) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	} else {
+		return nil
+	}
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryList) DeepCopyInto(out *RepositoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Repository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryList.
+func (in *RepositoryList) DeepCopy() *RepositoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RepositoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	} else {
+		return nil
+	}
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositorySpec) DeepCopyInto(out *RepositorySpec) {
+	*out = *in
+	in.Backend.DeepCopyInto(&out.Backend)
+	out.Workload = in.Workload
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositorySpec.
+func (in *RepositorySpec) DeepCopy() *RepositorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryStatus) DeepCopyInto(out *RepositoryStatus) {
+	*out = *in
+	if in.FirstBackupTime != nil {
+		in, out := &in.FirstBackupTime, &out.FirstBackupTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.Time)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.LastBackupTime != nil {
+		in, out := &in.LastBackupTime, &out.LastBackupTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.Time)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.LastSuccessfulBackupTime != nil {
+		in, out := &in.LastSuccessfulBackupTime, &out.LastSuccessfulBackupTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.Time)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]HostBackupStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = make([]MaintenanceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryStatus.
+func (in *RepositoryStatus) DeepCopy() *RepositoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestServerSpec) DeepCopyInto(out *RestServerSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestServerSpec.
+func (in *RestServerSpec) DeepCopy() *RestServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RestServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Restic) DeepCopyInto(out *Restic) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Restic.
+func (in *Restic) DeepCopy() *Restic {
+	if in == nil {
+		return nil
+	}
+	out := new(Restic)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Restic) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	} else {
+		return nil
+	}
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResticList) DeepCopyInto(out *ResticList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Restic, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticList.
+func (in *ResticList) DeepCopy() *ResticList {
+	if in == nil {
+		return nil
+	}
+	out := new(ResticList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResticList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	} else {
+		return nil
+	}
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResticSpec) DeepCopyInto(out *ResticSpec) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	if in.FileGroups != nil {
+		in, out := &in.FileGroups, &out.FileGroups
+		*out = make([]FileGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Backend.DeepCopyInto(&out.Backend)
+	if in.ScheduleJitter != nil {
+		in, out := &in.ScheduleJitter, &out.ScheduleJitter
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.Duration)
+			**out = **in
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.RetentionPolicies != nil {
+		in, out := &in.RetentionPolicies, &out.RetentionPolicies
+		*out = make([]RetentionPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PreBackup != nil {
+		in, out := &in.PreBackup, &out.PreBackup
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(BackupHook)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.PostBackup != nil {
+		in, out := &in.PostBackup, &out.PostBackup
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(BackupHook)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(MaintenanceSpec)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Bandwidth != nil {
+		in, out := &in.Bandwidth, &out.Bandwidth
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(BandwidthLimit)
+			**out = **in
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.Duration)
+			**out = **in
+		}
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(RetryPolicy)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticSpec.
+func (in *ResticSpec) DeepCopy() *ResticSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResticSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResticStatus) DeepCopyInto(out *ResticStatus) {
+	*out = *in
+	if in.FirstBackupTime != nil {
+		in, out := &in.FirstBackupTime, &out.FirstBackupTime
+		if *in == nil {
+			*out = nil
+		} else {
 			*out = new(meta_v1.Time)
 			(*in).DeepCopyInto(*out)
 		}
@@ -557,6 +1430,22 @@ func (in *ResticStatus) DeepCopyInto(out *ResticStatus) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(BackupTemplateSpec)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.ClusterRetentionPolicies != nil {
+		in, out := &in.ClusterRetentionPolicies, &out.ClusterRetentionPolicies
+		*out = make([]RetentionPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -586,6 +1475,37 @@ func (in *RestoreStats) DeepCopy() *RestoreStats {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreTarget) DeepCopyInto(out *RestoreTarget) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreTarget.
+func (in *RestoreTarget) DeepCopy() *RestoreTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RetentionPolicy) DeepCopyInto(out *RetentionPolicy) {
 	*out = *in
@@ -594,6 +1514,11 @@ func (in *RetentionPolicy) DeepCopyInto(out *RetentionPolicy) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.GroupBy != nil {
+		in, out := &in.GroupBy, &out.GroupBy
+		*out = make([]SnapshotGroupBy, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -607,6 +1532,31 @@ func (in *RetentionPolicy) DeepCopy() *RetentionPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.Duration)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *S3Spec) DeepCopyInto(out *S3Spec) {
 	*out = *in
@@ -623,6 +1573,43 @@ func (in *S3Spec) DeepCopy() *S3Spec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SFTPSpec) DeepCopyInto(out *SFTPSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SFTPSpec.
+func (in *SFTPSpec) DeepCopy() *SFTPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SFTPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StdinSource) DeepCopyInto(out *StdinSource) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StdinSource.
+func (in *StdinSource) DeepCopy() *StdinSource {
+	if in == nil {
+		return nil
+	}
+	out := new(StdinSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SwiftSpec) DeepCopyInto(out *SwiftSpec) {
 	*out = *in
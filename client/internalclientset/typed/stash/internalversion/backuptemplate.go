@@ -0,0 +1,145 @@
+/*
+Copyright 2018 The Stash Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internalversion
+
+import (
+	stash "github.com/appscode/stash/apis/stash"
+	scheme "github.com/appscode/stash/client/internalclientset/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// BackupTemplatesGetter has a method to return a BackupTemplateInterface.
+// A group's client should implement this interface.
+type BackupTemplatesGetter interface {
+	BackupTemplates() BackupTemplateInterface
+}
+
+// BackupTemplateInterface has methods to work with BackupTemplate resources.
+type BackupTemplateInterface interface {
+	Create(*stash.BackupTemplate) (*stash.BackupTemplate, error)
+	Update(*stash.BackupTemplate) (*stash.BackupTemplate, error)
+	Delete(name string, options *v1.DeleteOptions) error
+	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
+	Get(name string, options v1.GetOptions) (*stash.BackupTemplate, error)
+	List(opts v1.ListOptions) (*stash.BackupTemplateList, error)
+	Watch(opts v1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *stash.BackupTemplate, err error)
+	BackupTemplateExpansion
+}
+
+// backupTemplates implements BackupTemplateInterface
+type backupTemplates struct {
+	client rest.Interface
+}
+
+// newBackupTemplates returns a BackupTemplates
+func newBackupTemplates(c *StashClient) *backupTemplates {
+	return &backupTemplates{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the backupTemplate, and returns the corresponding backupTemplate object, and an error if there is any.
+func (c *backupTemplates) Get(name string, options v1.GetOptions) (result *stash.BackupTemplate, err error) {
+	result = &stash.BackupTemplate{}
+	err = c.client.Get().
+		Resource("backuptemplates").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of BackupTemplates that match those selectors.
+func (c *backupTemplates) List(opts v1.ListOptions) (result *stash.BackupTemplateList, err error) {
+	result = &stash.BackupTemplateList{}
+	err = c.client.Get().
+		Resource("backuptemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested backupTemplates.
+func (c *backupTemplates) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Resource("backuptemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch()
+}
+
+// Create takes the representation of a backupTemplate and creates it.  Returns the server's representation of the backupTemplate, and an error, if there is any.
+func (c *backupTemplates) Create(backupTemplate *stash.BackupTemplate) (result *stash.BackupTemplate, err error) {
+	result = &stash.BackupTemplate{}
+	err = c.client.Post().
+		Resource("backuptemplates").
+		Body(backupTemplate).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a backupTemplate and updates it. Returns the server's representation of the backupTemplate, and an error, if there is any.
+func (c *backupTemplates) Update(backupTemplate *stash.BackupTemplate) (result *stash.BackupTemplate, err error) {
+	result = &stash.BackupTemplate{}
+	err = c.client.Put().
+		Resource("backuptemplates").
+		Name(backupTemplate.Name).
+		Body(backupTemplate).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the backupTemplate and deletes it. Returns an error if one occurs.
+func (c *backupTemplates) Delete(name string, options *v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("backuptemplates").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *backupTemplates) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	return c.client.Delete().
+		Resource("backuptemplates").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched backupTemplate.
+func (c *backupTemplates) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *stash.BackupTemplate, err error) {
+	result = &stash.BackupTemplate{}
+	err = c.client.Patch(pt).
+		Resource("backuptemplates").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2018 The Stash Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internalversion
+
+import (
+	stash "github.com/appscode/stash/apis/stash"
+	scheme "github.com/appscode/stash/client/internalclientset/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ClusterRetentionPoliciesGetter has a method to return a ClusterRetentionPolicyInterface.
+// A group's client should implement this interface.
+type ClusterRetentionPoliciesGetter interface {
+	ClusterRetentionPolicies() ClusterRetentionPolicyInterface
+}
+
+// ClusterRetentionPolicyInterface has methods to work with ClusterRetentionPolicy resources.
+type ClusterRetentionPolicyInterface interface {
+	Create(*stash.ClusterRetentionPolicy) (*stash.ClusterRetentionPolicy, error)
+	Update(*stash.ClusterRetentionPolicy) (*stash.ClusterRetentionPolicy, error)
+	Delete(name string, options *v1.DeleteOptions) error
+	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
+	Get(name string, options v1.GetOptions) (*stash.ClusterRetentionPolicy, error)
+	List(opts v1.ListOptions) (*stash.ClusterRetentionPolicyList, error)
+	Watch(opts v1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *stash.ClusterRetentionPolicy, err error)
+	ClusterRetentionPolicyExpansion
+}
+
+// clusterRetentionPolicies implements ClusterRetentionPolicyInterface
+type clusterRetentionPolicies struct {
+	client rest.Interface
+}
+
+// newClusterRetentionPolicies returns a ClusterRetentionPolicies
+func newClusterRetentionPolicies(c *StashClient) *clusterRetentionPolicies {
+	return &clusterRetentionPolicies{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the clusterRetentionPolicy, and returns the corresponding clusterRetentionPolicy object, and an error if there is any.
+func (c *clusterRetentionPolicies) Get(name string, options v1.GetOptions) (result *stash.ClusterRetentionPolicy, err error) {
+	result = &stash.ClusterRetentionPolicy{}
+	err = c.client.Get().
+		Resource("clusterretentionpolicies").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ClusterRetentionPolicies that match those selectors.
+func (c *clusterRetentionPolicies) List(opts v1.ListOptions) (result *stash.ClusterRetentionPolicyList, err error) {
+	result = &stash.ClusterRetentionPolicyList{}
+	err = c.client.Get().
+		Resource("clusterretentionpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested clusterRetentionPolicies.
+func (c *clusterRetentionPolicies) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Resource("clusterretentionpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch()
+}
+
+// Create takes the representation of a clusterRetentionPolicy and creates it.  Returns the server's representation of the clusterRetentionPolicy, and an error, if there is any.
+func (c *clusterRetentionPolicies) Create(clusterRetentionPolicy *stash.ClusterRetentionPolicy) (result *stash.ClusterRetentionPolicy, err error) {
+	result = &stash.ClusterRetentionPolicy{}
+	err = c.client.Post().
+		Resource("clusterretentionpolicies").
+		Body(clusterRetentionPolicy).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a clusterRetentionPolicy and updates it. Returns the server's representation of the clusterRetentionPolicy, and an error, if there is any.
+func (c *clusterRetentionPolicies) Update(clusterRetentionPolicy *stash.ClusterRetentionPolicy) (result *stash.ClusterRetentionPolicy, err error) {
+	result = &stash.ClusterRetentionPolicy{}
+	err = c.client.Put().
+		Resource("clusterretentionpolicies").
+		Name(clusterRetentionPolicy.Name).
+		Body(clusterRetentionPolicy).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the clusterRetentionPolicy and deletes it. Returns an error if one occurs.
+func (c *clusterRetentionPolicies) Delete(name string, options *v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("clusterretentionpolicies").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *clusterRetentionPolicies) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	return c.client.Delete().
+		Resource("clusterretentionpolicies").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched clusterRetentionPolicy.
+func (c *clusterRetentionPolicies) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *stash.ClusterRetentionPolicy, err error) {
+	result = &stash.ClusterRetentionPolicy{}
+	err = c.client.Patch(pt).
+		Resource("clusterretentionpolicies").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2018 The Stash Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	stash "github.com/appscode/stash/apis/stash"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterRetentionPolicies implements ClusterRetentionPolicyInterface
+type FakeClusterRetentionPolicies struct {
+	Fake *FakeStash
+}
+
+var clusterretentionpoliciesResource = schema.GroupVersionResource{Group: "stash.appscode.com", Version: "", Resource: "clusterretentionpolicies"}
+
+var clusterretentionpoliciesKind = schema.GroupVersionKind{Group: "stash.appscode.com", Version: "", Kind: "ClusterRetentionPolicy"}
+
+// Get takes name of the clusterRetentionPolicy, and returns the corresponding clusterRetentionPolicy object, and an error if there is any.
+func (c *FakeClusterRetentionPolicies) Get(name string, options v1.GetOptions) (result *stash.ClusterRetentionPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(clusterretentionpoliciesResource, name), &stash.ClusterRetentionPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*stash.ClusterRetentionPolicy), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterRetentionPolicies that match those selectors.
+func (c *FakeClusterRetentionPolicies) List(opts v1.ListOptions) (result *stash.ClusterRetentionPolicyList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(clusterretentionpoliciesResource, clusterretentionpoliciesKind, opts), &stash.ClusterRetentionPolicyList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &stash.ClusterRetentionPolicyList{}
+	for _, item := range obj.(*stash.ClusterRetentionPolicyList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterRetentionPolicies.
+func (c *FakeClusterRetentionPolicies) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(clusterretentionpoliciesResource, opts))
+
+}
+
+// Create takes the representation of a clusterRetentionPolicy and creates it.  Returns the server's representation of the clusterRetentionPolicy, and an error, if there is any.
+func (c *FakeClusterRetentionPolicies) Create(clusterRetentionPolicy *stash.ClusterRetentionPolicy) (result *stash.ClusterRetentionPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(clusterretentionpoliciesResource, clusterRetentionPolicy), &stash.ClusterRetentionPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*stash.ClusterRetentionPolicy), err
+}
+
+// Update takes the representation of a clusterRetentionPolicy and updates it. Returns the server's representation of the clusterRetentionPolicy, and an error, if there is any.
+func (c *FakeClusterRetentionPolicies) Update(clusterRetentionPolicy *stash.ClusterRetentionPolicy) (result *stash.ClusterRetentionPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(clusterretentionpoliciesResource, clusterRetentionPolicy), &stash.ClusterRetentionPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*stash.ClusterRetentionPolicy), err
+}
+
+// Delete takes name of the clusterRetentionPolicy and deletes it. Returns an error if one occurs.
+func (c *FakeClusterRetentionPolicies) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(clusterretentionpoliciesResource, name), &stash.ClusterRetentionPolicy{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterRetentionPolicies) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(clusterretentionpoliciesResource, listOptions)
+
+	_, err := c.Fake.Invokes(action, &stash.ClusterRetentionPolicyList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterRetentionPolicy.
+func (c *FakeClusterRetentionPolicies) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *stash.ClusterRetentionPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusterretentionpoliciesResource, name, data, subresources...), &stash.ClusterRetentionPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*stash.ClusterRetentionPolicy), err
+}
@@ -26,10 +26,26 @@ type FakeStash struct {
 	*testing.Fake
 }
 
+func (c *FakeStash) BackupSessions(namespace string) internalversion.BackupSessionInterface {
+	return &FakeBackupSessions{c, namespace}
+}
+
+func (c *FakeStash) BackupTemplates() internalversion.BackupTemplateInterface {
+	return &FakeBackupTemplates{c}
+}
+
+func (c *FakeStash) ClusterRetentionPolicies() internalversion.ClusterRetentionPolicyInterface {
+	return &FakeClusterRetentionPolicies{c}
+}
+
 func (c *FakeStash) Recoveries(namespace string) internalversion.RecoveryInterface {
 	return &FakeRecoveries{c, namespace}
 }
 
+func (c *FakeStash) Repositories(namespace string) internalversion.RepositoryInterface {
+	return &FakeRepositories{c, namespace}
+}
+
 func (c *FakeStash) Restics(namespace string) internalversion.ResticInterface {
 	return &FakeRestics{c, namespace}
 }
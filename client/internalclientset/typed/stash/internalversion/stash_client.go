@@ -23,7 +23,11 @@ import (
 
 type StashInterface interface {
 	RESTClient() rest.Interface
+	BackupSessionsGetter
+	BackupTemplatesGetter
+	ClusterRetentionPoliciesGetter
 	RecoveriesGetter
+	RepositoriesGetter
 	ResticsGetter
 }
 
@@ -32,10 +36,26 @@ type StashClient struct {
 	restClient rest.Interface
 }
 
+func (c *StashClient) BackupSessions(namespace string) BackupSessionInterface {
+	return newBackupSessions(c, namespace)
+}
+
+func (c *StashClient) BackupTemplates() BackupTemplateInterface {
+	return newBackupTemplates(c)
+}
+
+func (c *StashClient) ClusterRetentionPolicies() ClusterRetentionPolicyInterface {
+	return newClusterRetentionPolicies(c)
+}
+
 func (c *StashClient) Recoveries(namespace string) RecoveryInterface {
 	return newRecoveries(c, namespace)
 }
 
+func (c *StashClient) Repositories(namespace string) RepositoryInterface {
+	return newRepositories(c, namespace)
+}
+
 func (c *StashClient) Restics(namespace string) ResticInterface {
 	return newRestics(c, namespace)
 }
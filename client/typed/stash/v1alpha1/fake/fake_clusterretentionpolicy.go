@@ -0,0 +1,125 @@
+/*
+Copyright 2018 The Stash Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	v1alpha1 "github.com/appscode/stash/apis/stash/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterRetentionPolicies implements ClusterRetentionPolicyInterface
+type FakeClusterRetentionPolicies struct {
+	Fake *FakeStashV1alpha1
+}
+
+var clusterretentionpoliciesResource = schema.GroupVersionResource{Group: "stash.appscode.com", Version: "v1alpha1", Resource: "clusterretentionpolicies"}
+
+var clusterretentionpoliciesKind = schema.GroupVersionKind{Group: "stash.appscode.com", Version: "v1alpha1", Kind: "ClusterRetentionPolicy"}
+
+// Get takes name of the clusterRetentionPolicy, and returns the corresponding clusterRetentionPolicy object, and an error if there is any.
+func (c *FakeClusterRetentionPolicies) Get(name string, options v1.GetOptions) (result *v1alpha1.ClusterRetentionPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(clusterretentionpoliciesResource, name), &v1alpha1.ClusterRetentionPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterRetentionPolicy), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterRetentionPolicies that match those selectors.
+func (c *FakeClusterRetentionPolicies) List(opts v1.ListOptions) (result *v1alpha1.ClusterRetentionPolicyList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(clusterretentionpoliciesResource, clusterretentionpoliciesKind, opts), &v1alpha1.ClusterRetentionPolicyList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ClusterRetentionPolicyList{}
+	for _, item := range obj.(*v1alpha1.ClusterRetentionPolicyList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterRetentionPolicies.
+func (c *FakeClusterRetentionPolicies) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(clusterretentionpoliciesResource, opts))
+
+}
+
+// Create takes the representation of a clusterRetentionPolicy and creates it.  Returns the server's representation of the clusterRetentionPolicy, and an error, if there is any.
+func (c *FakeClusterRetentionPolicies) Create(clusterRetentionPolicy *v1alpha1.ClusterRetentionPolicy) (result *v1alpha1.ClusterRetentionPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(clusterretentionpoliciesResource, clusterRetentionPolicy), &v1alpha1.ClusterRetentionPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterRetentionPolicy), err
+}
+
+// Update takes the representation of a clusterRetentionPolicy and updates it. Returns the server's representation of the clusterRetentionPolicy, and an error, if there is any.
+func (c *FakeClusterRetentionPolicies) Update(clusterRetentionPolicy *v1alpha1.ClusterRetentionPolicy) (result *v1alpha1.ClusterRetentionPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(clusterretentionpoliciesResource, clusterRetentionPolicy), &v1alpha1.ClusterRetentionPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterRetentionPolicy), err
+}
+
+// Delete takes name of the clusterRetentionPolicy and deletes it. Returns an error if one occurs.
+func (c *FakeClusterRetentionPolicies) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(clusterretentionpoliciesResource, name), &v1alpha1.ClusterRetentionPolicy{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterRetentionPolicies) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(clusterretentionpoliciesResource, listOptions)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ClusterRetentionPolicyList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterRetentionPolicy.
+func (c *FakeClusterRetentionPolicies) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.ClusterRetentionPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusterretentionpoliciesResource, name, data, subresources...), &v1alpha1.ClusterRetentionPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterRetentionPolicy), err
+}
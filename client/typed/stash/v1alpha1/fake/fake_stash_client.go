@@ -26,10 +26,26 @@ type FakeStashV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeStashV1alpha1) BackupSessions(namespace string) v1alpha1.BackupSessionInterface {
+	return &FakeBackupSessions{c, namespace}
+}
+
+func (c *FakeStashV1alpha1) BackupTemplates() v1alpha1.BackupTemplateInterface {
+	return &FakeBackupTemplates{c}
+}
+
+func (c *FakeStashV1alpha1) ClusterRetentionPolicies() v1alpha1.ClusterRetentionPolicyInterface {
+	return &FakeClusterRetentionPolicies{c}
+}
+
 func (c *FakeStashV1alpha1) Recoveries(namespace string) v1alpha1.RecoveryInterface {
 	return &FakeRecoveries{c, namespace}
 }
 
+func (c *FakeStashV1alpha1) Repositories(namespace string) v1alpha1.RepositoryInterface {
+	return &FakeRepositories{c, namespace}
+}
+
 func (c *FakeStashV1alpha1) Restics(namespace string) v1alpha1.ResticInterface {
 	return &FakeRestics{c, namespace}
 }
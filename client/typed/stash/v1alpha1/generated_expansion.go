@@ -16,6 +16,14 @@ limitations under the License.
 
 package v1alpha1
 
+type BackupSessionExpansion interface{}
+
+type BackupTemplateExpansion interface{}
+
+type ClusterRetentionPolicyExpansion interface{}
+
 type RecoveryExpansion interface{}
 
+type RepositoryExpansion interface{}
+
 type ResticExpansion interface{}
@@ -25,7 +25,11 @@ import (
 
 type StashV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	BackupSessionsGetter
+	BackupTemplatesGetter
+	ClusterRetentionPoliciesGetter
 	RecoveriesGetter
+	RepositoriesGetter
 	ResticsGetter
 }
 
@@ -34,10 +38,26 @@ type StashV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *StashV1alpha1Client) BackupSessions(namespace string) BackupSessionInterface {
+	return newBackupSessions(c, namespace)
+}
+
+func (c *StashV1alpha1Client) BackupTemplates() BackupTemplateInterface {
+	return newBackupTemplates(c)
+}
+
+func (c *StashV1alpha1Client) ClusterRetentionPolicies() ClusterRetentionPolicyInterface {
+	return newClusterRetentionPolicies(c)
+}
+
 func (c *StashV1alpha1Client) Recoveries(namespace string) RecoveryInterface {
 	return newRecoveries(c, namespace)
 }
 
+func (c *StashV1alpha1Client) Repositories(namespace string) RepositoryInterface {
+	return newRepositories(c, namespace)
+}
+
 func (c *StashV1alpha1Client) Restics(namespace string) ResticInterface {
 	return newRestics(c, namespace)
 }
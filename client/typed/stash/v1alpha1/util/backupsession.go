@@ -0,0 +1,78 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/appscode/kutil"
+	api "github.com/appscode/stash/apis/stash/v1alpha1"
+	cs "github.com/appscode/stash/client/typed/stash/v1alpha1"
+	"github.com/golang/glog"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/jsonmergepatch"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func CreateOrPatchBackupSession(c cs.StashV1alpha1Interface, meta metav1.ObjectMeta, transform func(alert *api.BackupSession) *api.BackupSession) (*api.BackupSession, kutil.VerbType, error) {
+	cur, err := c.BackupSessions(meta.Namespace).Get(meta.Name, metav1.GetOptions{})
+	if kerr.IsNotFound(err) {
+		glog.V(3).Infof("Creating BackupSession %s/%s.", meta.Namespace, meta.Name)
+		out, err := c.BackupSessions(meta.Namespace).Create(transform(&api.BackupSession{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "BackupSession",
+				APIVersion: api.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: meta,
+		}))
+		return out, kutil.VerbCreated, err
+	} else if err != nil {
+		return nil, kutil.VerbUnchanged, err
+	}
+	return PatchBackupSession(c, cur, transform)
+}
+
+func PatchBackupSession(c cs.StashV1alpha1Interface, cur *api.BackupSession, transform func(*api.BackupSession) *api.BackupSession) (*api.BackupSession, kutil.VerbType, error) {
+	curJson, err := json.Marshal(cur)
+	if err != nil {
+		return nil, kutil.VerbUnchanged, err
+	}
+
+	modJson, err := json.Marshal(transform(cur.DeepCopy()))
+	if err != nil {
+		return nil, kutil.VerbUnchanged, err
+	}
+
+	patch, err := jsonmergepatch.CreateThreeWayJSONMergePatch(curJson, modJson, curJson)
+	if err != nil {
+		return nil, kutil.VerbUnchanged, err
+	}
+	if len(patch) == 0 || string(patch) == "{}" {
+		return cur, kutil.VerbUnchanged, nil
+	}
+	glog.V(3).Infof("Patching BackupSession %s/%s with %s.", cur.Namespace, cur.Name, string(patch))
+	out, err := c.BackupSessions(cur.Namespace).Patch(cur.Name, types.MergePatchType, patch)
+	return out, kutil.VerbPatched, err
+}
+
+func TryUpdateBackupSession(c cs.StashV1alpha1Interface, meta metav1.ObjectMeta, transform func(*api.BackupSession) *api.BackupSession) (result *api.BackupSession, err error) {
+	attempt := 0
+	err = wait.PollImmediate(kutil.RetryInterval, kutil.RetryTimeout, func() (bool, error) {
+		attempt++
+		cur, e2 := c.BackupSessions(meta.Namespace).Get(meta.Name, metav1.GetOptions{})
+		if kerr.IsNotFound(e2) {
+			return false, e2
+		} else if e2 == nil {
+			result, e2 = c.BackupSessions(cur.Namespace).Update(transform(cur.DeepCopy()))
+			return e2 == nil, nil
+		}
+		glog.Errorf("Attempt %d failed to update BackupSession %s/%s due to %v.", attempt, cur.Namespace, cur.Name, e2)
+		return false, nil
+	})
+
+	if err != nil {
+		err = fmt.Errorf("failed to update BackupSession %s/%s after %d attempts due to %v", meta.Namespace, meta.Name, attempt, err)
+	}
+	return
+}
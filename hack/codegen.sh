@@ -34,6 +34,7 @@ docker run --rm -ti -u $(id -u):$(id -g) \
     --go-header-file "hack/gengo/boilerplate.go.txt" \
     --input-dirs "$PACKAGE_NAME/apis/stash" \
     --input-dirs "$PACKAGE_NAME/apis/stash/v1alpha1" \
+    --input-dirs "$PACKAGE_NAME/apis/repositories/v1alpha1" \
     --output-file-base zz_generated.deepcopy
 
 # Generate conversions
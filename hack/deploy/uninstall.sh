@@ -9,5 +9,9 @@ kubectl delete secret -l app=stash -n kube-system
 kubectl delete serviceaccount -l app=stash -n kube-system
 kubectl delete clusterrolebindings -l app=stash -n kube-system
 kubectl delete clusterrole -l app=stash -n kube-system
+kubectl delete rolebindings -l app=stash -n kube-system
 
 kubectl delete initializerconfiguration -l app=stash
+kubectl delete apiservice -l app=stash
+kubectl delete validatingwebhookconfiguration -l app=stash
+kubectl delete mutatingwebhookconfiguration -l app=stash
@@ -52,8 +52,16 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=Stash, Version=V1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("backupsessions"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Stash().V1alpha1().BackupSessions().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("backuptemplates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Stash().V1alpha1().BackupTemplates().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("clusterretentionpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Stash().V1alpha1().ClusterRetentionPolicies().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("recoveries"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Stash().V1alpha1().Recoveries().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("repositories"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Stash().V1alpha1().Repositories().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("restics"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Stash().V1alpha1().Restics().Informer()}, nil
 
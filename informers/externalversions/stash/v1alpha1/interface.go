@@ -24,8 +24,16 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// BackupSessions returns a BackupSessionInformer.
+	BackupSessions() BackupSessionInformer
+	// BackupTemplates returns a BackupTemplateInformer.
+	BackupTemplates() BackupTemplateInformer
+	// ClusterRetentionPolicies returns a ClusterRetentionPolicyInformer.
+	ClusterRetentionPolicies() ClusterRetentionPolicyInformer
 	// Recoveries returns a RecoveryInformer.
 	Recoveries() RecoveryInformer
+	// Repositories returns a RepositoryInformer.
+	Repositories() RepositoryInformer
 	// Restics returns a ResticInformer.
 	Restics() ResticInformer
 }
@@ -39,11 +47,31 @@ func New(f internalinterfaces.SharedInformerFactory) Interface {
 	return &version{f}
 }
 
+// BackupSessions returns a BackupSessionInformer.
+func (v *version) BackupSessions() BackupSessionInformer {
+	return &backupSessionInformer{factory: v.SharedInformerFactory}
+}
+
+// BackupTemplates returns a BackupTemplateInformer.
+func (v *version) BackupTemplates() BackupTemplateInformer {
+	return &backupTemplateInformer{factory: v.SharedInformerFactory}
+}
+
+// ClusterRetentionPolicies returns a ClusterRetentionPolicyInformer.
+func (v *version) ClusterRetentionPolicies() ClusterRetentionPolicyInformer {
+	return &clusterRetentionPolicyInformer{factory: v.SharedInformerFactory}
+}
+
 // Recoveries returns a RecoveryInformer.
 func (v *version) Recoveries() RecoveryInformer {
 	return &recoveryInformer{factory: v.SharedInformerFactory}
 }
 
+// Repositories returns a RepositoryInformer.
+func (v *version) Repositories() RepositoryInformer {
+	return &repositoryInformer{factory: v.SharedInformerFactory}
+}
+
 // Restics returns a ResticInformer.
 func (v *version) Restics() ResticInformer {
 	return &resticInformer{factory: v.SharedInformerFactory}
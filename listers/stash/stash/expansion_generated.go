@@ -18,6 +18,22 @@ limitations under the License.
 
 package stash
 
+// BackupSessionListerExpansion allows custom methods to be added to
+// BackupSessionLister.
+type BackupSessionListerExpansion interface{}
+
+// BackupSessionNamespaceListerExpansion allows custom methods to be added to
+// BackupSessionNamespaceLister.
+type BackupSessionNamespaceListerExpansion interface{}
+
+// BackupTemplateListerExpansion allows custom methods to be added to
+// BackupTemplateLister.
+type BackupTemplateListerExpansion interface{}
+
+// ClusterRetentionPolicyListerExpansion allows custom methods to be added to
+// ClusterRetentionPolicyLister.
+type ClusterRetentionPolicyListerExpansion interface{}
+
 // RecoveryListerExpansion allows custom methods to be added to
 // RecoveryLister.
 type RecoveryListerExpansion interface{}
@@ -26,6 +42,14 @@ type RecoveryListerExpansion interface{}
 // RecoveryNamespaceLister.
 type RecoveryNamespaceListerExpansion interface{}
 
+// RepositoryListerExpansion allows custom methods to be added to
+// RepositoryLister.
+type RepositoryListerExpansion interface{}
+
+// RepositoryNamespaceListerExpansion allows custom methods to be added to
+// RepositoryNamespaceLister.
+type RepositoryNamespaceListerExpansion interface{}
+
 // ResticListerExpansion allows custom methods to be added to
 // ResticLister.
 type ResticListerExpansion interface{}
@@ -0,0 +1,85 @@
+package admission
+
+import (
+	admissionregistration "k8s.io/api/admissionregistration/v1alpha1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// The types below mirror admission.k8s.io/v1beta1 which is not part of the vendored k8s.io/api.
+// Only the fields used by Stash are included.
+
+const (
+	Create Operation = "CREATE"
+	Update Operation = "UPDATE"
+	Delete Operation = "DELETE"
+)
+
+type Operation string
+
+const PatchTypeJSONPatch PatchType = "JSONPatch"
+
+type PatchType string
+
+// AdmissionReview is sent by kube-apiserver to admission webhooks and returned with Response set.
+type AdmissionReview struct {
+	metav1.TypeMeta `json:",inline"`
+	Request         *AdmissionRequest  `json:"request,omitempty"`
+	Response        *AdmissionResponse `json:"response,omitempty"`
+}
+
+type AdmissionRequest struct {
+	UID         types.UID                   `json:"uid"`
+	Kind        metav1.GroupVersionKind     `json:"kind"`
+	Resource    metav1.GroupVersionResource `json:"resource"`
+	SubResource string                      `json:"subResource,omitempty"`
+	Name        string                      `json:"name,omitempty"`
+	Namespace   string                      `json:"namespace,omitempty"`
+	Operation   Operation                   `json:"operation"`
+	UserInfo    authenticationv1.UserInfo   `json:"userInfo"`
+	Object      runtime.RawExtension        `json:"object,omitempty"`
+	OldObject   runtime.RawExtension        `json:"oldObject,omitempty"`
+}
+
+type AdmissionResponse struct {
+	UID       types.UID      `json:"uid"`
+	Allowed   bool           `json:"allowed"`
+	Result    *metav1.Status `json:"result,omitempty"`
+	Patch     []byte         `json:"patch,omitempty"`
+	PatchType *PatchType     `json:"patchType,omitempty"`
+}
+
+// PatchOperation is a single JSON Patch (RFC 6902) operation.
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// webhookConfiguration mirrors ValidatingWebhookConfiguration and MutatingWebhookConfiguration
+// of admissionregistration.k8s.io/v1beta1. Rules use the identical v1alpha1 types.
+type webhookConfiguration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Webhooks          []webhook `json:"webhooks,omitempty"`
+}
+
+type webhook struct {
+	Name          string                                     `json:"name"`
+	ClientConfig  webhookClientConfig                        `json:"clientConfig"`
+	Rules         []admissionregistration.RuleWithOperations `json:"rules,omitempty"`
+	FailurePolicy *admissionregistration.FailurePolicyType   `json:"failurePolicy,omitempty"`
+}
+
+type webhookClientConfig struct {
+	Service  *serviceReference `json:"service,omitempty"`
+	CABundle []byte            `json:"caBundle"`
+}
+
+type serviceReference struct {
+	Namespace string  `json:"namespace"`
+	Name      string  `json:"name"`
+	Path      *string `json:"path,omitempty"`
+}
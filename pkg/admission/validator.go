@@ -0,0 +1,205 @@
+package admission
+
+import (
+	"fmt"
+
+	api "github.com/appscode/stash/apis/stash/v1alpha1"
+	cs "github.com/appscode/stash/client/typed/stash/v1alpha1"
+	"github.com/appscode/stash/pkg/cli"
+	"github.com/appscode/stash/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Validator checks Restic, Recovery and BackupSession objects before they are stored. Unlike IsValid,
+// it also verifies the objects against the current state of the cluster.
+type Validator struct {
+	kubeClient  kubernetes.Interface
+	stashClient cs.StashV1alpha1Interface
+}
+
+func NewValidator(kubeClient kubernetes.Interface, stashClient cs.StashV1alpha1Interface) *Validator {
+	return &Validator{
+		kubeClient:  kubeClient,
+		stashClient: stashClient,
+	}
+}
+
+func (v *Validator) ValidateRestic(restic *api.Restic) error {
+	restic, err := v.resolveRestic(restic)
+	if err != nil {
+		return err
+	}
+	if err := restic.IsValid(); err != nil {
+		return err
+	}
+	if err := v.checkStorageSecret(restic.Namespace, restic.Spec.Backend); err != nil {
+		return err
+	}
+	if err := checkFileGroupPaths(restic); err != nil {
+		return err
+	}
+	return v.checkSelectorOverlap(restic)
+}
+
+func (v *Validator) ValidateRecovery(rec *api.Recovery) error {
+	if err := rec.IsValid(); err != nil {
+		return err
+	}
+	return v.checkStorageSecret(rec.Namespace, rec.Spec.Backend)
+}
+
+// ValidateBackupSession ensures that the Restic of session exists and its sidecars can take
+// an on-demand backup. Offline backups run in an init container, so they can't be triggered.
+func (v *Validator) ValidateBackupSession(session *api.BackupSession) error {
+	if err := session.IsValid(); err != nil {
+		return err
+	}
+	restic, err := v.stashClient.Restics(session.Namespace).Get(session.Spec.Restic, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get Restic %s, reason: %s", session.Spec.Restic, err)
+	}
+	if restic.Spec.Type == api.BackupOffline {
+		return fmt.Errorf("on-demand backup is not supported for offline Restic %s", restic.Name)
+	}
+	return nil
+}
+
+// resolveRestic returns restic resolved with its BackupTemplate and ClusterRetentionPolicies,
+// so that a Restic relying on them is validated with the values used by the sidecars.
+func (v *Validator) resolveRestic(restic *api.Restic) (*api.Restic, error) {
+	template, policies, err := util.ResticTemplates(
+		restic,
+		func(name string) (*api.BackupTemplate, error) {
+			return v.stashClient.BackupTemplates().Get(name, metav1.GetOptions{})
+		},
+		func(name string) (*api.ClusterRetentionPolicy, error) {
+			return v.stashClient.ClusterRetentionPolicies().Get(name, metav1.GetOptions{})
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	out := restic.DeepCopy()
+	out.Status.Template = template
+	out.Status.ClusterRetentionPolicies = policies
+	return out.Resolved(), nil
+}
+
+// checkStorageSecret ensures that the storage secret exists and has the keys needed by backend.
+func (v *Validator) checkStorageSecret(namespace string, backend api.Backend) error {
+	if backend.StorageSecretName == "" {
+		return fmt.Errorf("missing repository secret name")
+	}
+	secret, err := v.kubeClient.CoreV1().Secrets(namespace).Get(backend.StorageSecretName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get storage secret %s, reason: %s", backend.StorageSecretName, err)
+	}
+	for _, key := range cli.RequiredSecretKeys(backend) {
+		if _, ok := secret.Data[key]; !ok {
+			return fmt.Errorf("storage secret %s is missing key %s", backend.StorageSecretName, key)
+		}
+	}
+	return nil
+}
+
+// checkFileGroupPaths ensures that backed up paths are mounted in the sidecar.
+// fileGroups read from stdin do not use a volume.
+func checkFileGroupPaths(restic *api.Restic) error {
+	for i, fg := range restic.Spec.FileGroups {
+		if fg.Stdin != nil {
+			continue
+		}
+		found := false
+		for _, mount := range restic.Spec.VolumeMounts {
+			if api.IsSubPath(mount.MountPath, fg.Path) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("spec.fileGroups[%d].path %s is not under any of spec.volumeMounts", i, fg.Path)
+		}
+	}
+	return nil
+}
+
+// checkSelectorOverlap rejects restic if a workload can be selected by it and another Restic
+// in the same namespace. A workload can match only one Restic.
+func (v *Validator) checkSelectorOverlap(restic *api.Restic) error {
+	restics, err := v.stashClient.Restics(restic.Namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, other := range restics.Items {
+		if other.Name == restic.Name {
+			continue
+		}
+		overlap, err := selectorsOverlap(restic.Spec.Selector, other.Spec.Selector)
+		if err != nil {
+			return err
+		}
+		if overlap {
+			return fmt.Errorf("spec.selector overlaps with selector of Restic %s/%s", other.Namespace, other.Name)
+		}
+	}
+	return nil
+}
+
+// selectorsOverlap checks whether a set of labels exists that is matched by both selectors.
+// Labels are independent of each other, so the selectors overlap if the requirements of both
+// selectors on each label can be met at the same time.
+func selectorsOverlap(a, b metav1.LabelSelector) (bool, error) {
+	requirements := map[string][]metav1.LabelSelectorRequirement{}
+	for _, sel := range []metav1.LabelSelector{a, b} {
+		if _, err := metav1.LabelSelectorAsSelector(&sel); err != nil {
+			return false, err
+		}
+		for k, v := range sel.MatchLabels {
+			requirements[k] = append(requirements[k], metav1.LabelSelectorRequirement{
+				Key:      k,
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   []string{v},
+			})
+		}
+		for _, r := range sel.MatchExpressions {
+			requirements[r.Key] = append(requirements[r.Key], r)
+		}
+	}
+	for _, reqs := range requirements {
+		if !satisfiable(reqs) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// satisfiable checks whether a value of a label, or its absence, meets all requirements on it.
+func satisfiable(reqs []metav1.LabelSelectorRequirement) bool {
+	var in sets.String // nil if any value is allowed
+	notIn := sets.NewString()
+	exists, notExists := false, false
+	for _, r := range reqs {
+		switch r.Operator {
+		case metav1.LabelSelectorOpIn:
+			exists = true
+			if in == nil {
+				in = sets.NewString(r.Values...)
+			} else {
+				in = in.Intersection(sets.NewString(r.Values...))
+			}
+		case metav1.LabelSelectorOpNotIn:
+			notIn.Insert(r.Values...)
+		case metav1.LabelSelectorOpExists:
+			exists = true
+		case metav1.LabelSelectorOpDoesNotExist:
+			notExists = true
+		}
+	}
+	if notExists {
+		// NotIn is met by a missing label
+		return !exists
+	}
+	return in == nil || in.Difference(notIn).Len() > 0
+}
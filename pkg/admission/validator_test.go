@@ -0,0 +1,124 @@
+package admission
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSelectorsOverlap(t *testing.T) {
+	req := func(key string, op metav1.LabelSelectorOperator, values ...string) metav1.LabelSelectorRequirement {
+		return metav1.LabelSelectorRequirement{Key: key, Operator: op, Values: values}
+	}
+	cases := []struct {
+		name     string
+		a        metav1.LabelSelector
+		b        metav1.LabelSelector
+		expected bool
+	}{
+		{
+			name:     "same labels",
+			a:        metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+			b:        metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+			expected: true,
+		},
+		{
+			name:     "different values",
+			a:        metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+			b:        metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			expected: false,
+		},
+		{
+			name:     "different keys",
+			a:        metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+			b:        metav1.LabelSelector{MatchLabels: map[string]string{"tier": "backend"}},
+			expected: true,
+		},
+		{
+			name:     "empty selector",
+			a:        metav1.LabelSelector{},
+			b:        metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+			expected: true,
+		},
+		{
+			name:     "In with common value",
+			a:        metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{req("app", metav1.LabelSelectorOpIn, "db", "cache")}},
+			b:        metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{req("app", metav1.LabelSelectorOpIn, "cache", "web")}},
+			expected: true,
+		},
+		{
+			name:     "In without common value",
+			a:        metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{req("app", metav1.LabelSelectorOpIn, "db", "cache")}},
+			b:        metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{req("app", metav1.LabelSelectorOpIn, "web")}},
+			expected: false,
+		},
+		{
+			name:     "NotIn excluding label",
+			a:        metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+			b:        metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{req("app", metav1.LabelSelectorOpNotIn, "db")}},
+			expected: false,
+		},
+		{
+			name:     "NotIn excluding some values",
+			a:        metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{req("app", metav1.LabelSelectorOpIn, "db", "cache")}},
+			b:        metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{req("app", metav1.LabelSelectorOpNotIn, "db")}},
+			expected: true,
+		},
+		{
+			name:     "NotIn on both",
+			a:        metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{req("app", metav1.LabelSelectorOpNotIn, "db")}},
+			b:        metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{req("app", metav1.LabelSelectorOpNotIn, "web")}},
+			expected: true,
+		},
+		{
+			name:     "Exists and label",
+			a:        metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{req("app", metav1.LabelSelectorOpExists)}},
+			b:        metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+			expected: true,
+		},
+		{
+			name:     "DoesNotExist and label",
+			a:        metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{req("app", metav1.LabelSelectorOpDoesNotExist)}},
+			b:        metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+			expected: false,
+		},
+		{
+			name:     "DoesNotExist and Exists",
+			a:        metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{req("app", metav1.LabelSelectorOpDoesNotExist)}},
+			b:        metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{req("app", metav1.LabelSelectorOpExists)}},
+			expected: false,
+		},
+		{
+			name:     "DoesNotExist and NotIn",
+			a:        metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{req("app", metav1.LabelSelectorOpDoesNotExist)}},
+			b:        metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{req("app", metav1.LabelSelectorOpNotIn, "db")}},
+			expected: true,
+		},
+		{
+			name: "one label conflicts",
+			a:    metav1.LabelSelector{MatchLabels: map[string]string{"app": "db", "tier": "backend"}},
+			b: metav1.LabelSelector{
+				MatchLabels:      map[string]string{"app": "db"},
+				MatchExpressions: []metav1.LabelSelectorRequirement{req("tier", metav1.LabelSelectorOpIn, "frontend")},
+			},
+			expected: false,
+		},
+	}
+	for _, c := range cases {
+		actual, err := selectorsOverlap(c.a, c.b)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", c.name, err)
+		} else if actual != c.expected {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, actual)
+		}
+		// overlap is symmetric
+		if actual, err = selectorsOverlap(c.b, c.a); err == nil && actual != c.expected {
+			t.Errorf("%s: expected %v for swapped selectors, got %v", c.name, c.expected, actual)
+		}
+	}
+
+	invalid := metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{req("app", metav1.LabelSelectorOpIn)}}
+	if _, err := selectorsOverlap(invalid, metav1.LabelSelector{}); err == nil {
+		t.Errorf("expected error for In without values")
+	}
+}
@@ -0,0 +1,151 @@
+package admission
+
+import (
+	"encoding/json"
+
+	"github.com/appscode/stash/apis/stash"
+	admissionregistration "k8s.io/api/admissionregistration/v1alpha1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	ValidatingWebhookPath = "/admission/v1beta1/validate"
+	MutatingWebhookPath   = "/admission/v1beta1/mutate"
+
+	webhookName         = "admission.stash.appscode.com"
+	workloadWebhookName = "workload.admission.stash.appscode.com"
+)
+
+// EnsureValidatingWebhookConfiguration registers the operator as validating webhook for Restic,
+// Recovery and BackupSession objects. Requests are sent to the operator's service serviceName in serviceNamespace.
+// caBundle must contain the CA of the operator's serving certificate.
+// This requires admissionregistration.k8s.io/v1beta1, available since Kubernetes 1.9.
+func EnsureValidatingWebhookConfiguration(kubeClient kubernetes.Interface, serviceName, serviceNamespace string, caBundle []byte) error {
+	path := ValidatingWebhookPath
+	policy := admissionregistration.Ignore
+	cfg := &webhookConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "admissionregistration.k8s.io/v1beta1",
+			Kind:       "ValidatingWebhookConfiguration",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   webhookName,
+			Labels: map[string]string{"app": "stash"},
+		},
+		Webhooks: []webhook{
+			{
+				Name: webhookName,
+				ClientConfig: webhookClientConfig{
+					Service: &serviceReference{
+						Namespace: serviceNamespace,
+						Name:      serviceName,
+						Path:      &path,
+					},
+					CABundle: caBundle,
+				},
+				Rules: []admissionregistration.RuleWithOperations{
+					{
+						Operations: []admissionregistration.OperationType{
+							admissionregistration.Create,
+							admissionregistration.Update,
+						},
+						Rule: admissionregistration.Rule{
+							APIGroups:   []string{stash.GroupName},
+							APIVersions: []string{"*"},
+							Resources:   []string{stash.ResourceTypeRestic, stash.ResourceTypeRecovery, stash.ResourceTypeBackupSession},
+						},
+					},
+				},
+				FailurePolicy: &policy,
+			},
+		},
+	}
+	return ensureWebhookConfiguration(kubeClient, "validatingwebhookconfigurations", cfg)
+}
+
+// WorkloadMutator computes the JSON patch applied to a workload at admission time.
+type WorkloadMutator interface {
+	MutateWorkload(req *AdmissionRequest) ([]PatchOperation, error)
+}
+
+// EnsureMutatingWebhookConfiguration registers the operator as mutating webhook for workloads,
+// so that stash sidecar is injected before workload pods are created.
+// This requires admissionregistration.k8s.io/v1beta1, available since Kubernetes 1.9.
+func EnsureMutatingWebhookConfiguration(kubeClient kubernetes.Interface, serviceName, serviceNamespace string, caBundle []byte) error {
+	path := MutatingWebhookPath
+	policy := admissionregistration.Ignore
+	operations := []admissionregistration.OperationType{
+		admissionregistration.Create,
+		admissionregistration.Update,
+	}
+	cfg := &webhookConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "admissionregistration.k8s.io/v1beta1",
+			Kind:       "MutatingWebhookConfiguration",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   workloadWebhookName,
+			Labels: map[string]string{"app": "stash"},
+		},
+		Webhooks: []webhook{
+			{
+				Name: workloadWebhookName,
+				ClientConfig: webhookClientConfig{
+					Service: &serviceReference{
+						Namespace: serviceNamespace,
+						Name:      serviceName,
+						Path:      &path,
+					},
+					CABundle: caBundle,
+				},
+				Rules: []admissionregistration.RuleWithOperations{
+					{
+						Operations: operations,
+						Rule: admissionregistration.Rule{
+							APIGroups:   []string{"apps"},
+							APIVersions: []string{"*"},
+							Resources:   []string{"deployments", "statefulsets", "daemonsets", "replicasets"},
+						},
+					},
+					{
+						Operations: operations,
+						Rule: admissionregistration.Rule{
+							APIGroups:   []string{"extensions"},
+							APIVersions: []string{"*"},
+							Resources:   []string{"deployments", "daemonsets", "replicasets"},
+						},
+					},
+					{
+						Operations: operations,
+						Rule: admissionregistration.Rule{
+							APIGroups:   []string{""},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"replicationcontrollers"},
+						},
+					},
+				},
+				FailurePolicy: &policy,
+			},
+		},
+	}
+	return ensureWebhookConfiguration(kubeClient, "mutatingwebhookconfigurations", cfg)
+}
+
+// ensureWebhookConfiguration creates or replaces cfg. Vendored client-go has no typed client for
+// admissionregistration.k8s.io/v1beta1, so the request is sent as raw JSON.
+func ensureWebhookConfiguration(kubeClient kubernetes.Interface, resource string, cfg *webhookConfiguration) error {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	path := "/apis/admissionregistration.k8s.io/v1beta1/" + resource
+	client := kubeClient.AdmissionregistrationV1alpha1().RESTClient()
+	err = client.Post().AbsPath(path).Body(data).Do().Error()
+	if kerr.IsAlreadyExists(err) {
+		err = client.Patch(types.MergePatchType).AbsPath(path, cfg.Name).Body(data).Do().Error()
+	}
+	return err
+}
@@ -0,0 +1,123 @@
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/appscode/go/log"
+	api "github.com/appscode/stash/apis/stash/v1alpha1"
+	"github.com/appscode/stash/pkg/admission"
+	"github.com/appscode/stash/pkg/util"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// validate serves the ValidatingAdmissionWebhook for Restic, Recovery and BackupSession. Requests come
+// directly from kube-apiserver, so they are not authenticated using request headers.
+func (s *Server) validate(w http.ResponseWriter, r *http.Request) {
+	review := admission.AdmissionReview{}
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+		writeError(w, kerr.NewBadRequest("failed to decode AdmissionReview, reason: "+err.Error()))
+		return
+	}
+	if review.Request == nil {
+		writeError(w, kerr.NewBadRequest("missing AdmissionReview request"))
+		return
+	}
+	review.Response = s.review(review.Request)
+	review.Request = nil
+	writeJSON(w, http.StatusOK, &review)
+}
+
+// mutate serves the MutatingAdmissionWebhook that injects stash sidecar into workloads.
+// Workloads are never rejected. If the sidecar can't be injected, the workload controller
+// retries later and reports the error.
+func (s *Server) mutate(w http.ResponseWriter, r *http.Request) {
+	review := admission.AdmissionReview{}
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+		writeError(w, kerr.NewBadRequest("failed to decode AdmissionReview, reason: "+err.Error()))
+		return
+	}
+	if review.Request == nil {
+		writeError(w, kerr.NewBadRequest("missing AdmissionReview request"))
+		return
+	}
+	req := review.Request
+	resp := &admission.AdmissionResponse{
+		UID:     req.UID,
+		Allowed: true,
+	}
+	patch, err := s.mutator.MutateWorkload(req)
+	if err != nil {
+		log.Errorf("Failed to inject sidecar into %s %s/%s, reason: %s", req.Kind.Kind, req.Namespace, req.Name, err)
+	} else if len(patch) > 0 {
+		if resp.Patch, err = json.Marshal(patch); err != nil {
+			writeError(w, err)
+			return
+		}
+		pt := admission.PatchTypeJSONPatch
+		resp.PatchType = &pt
+	}
+	review.Request = nil
+	review.Response = resp
+	writeJSON(w, http.StatusOK, &review)
+}
+
+func (s *Server) review(req *admission.AdmissionRequest) *admission.AdmissionResponse {
+	resp := &admission.AdmissionResponse{
+		UID:     req.UID,
+		Allowed: true,
+	}
+
+	var err error
+	switch req.Kind.Kind {
+	case api.ResourceKindRestic:
+		restic := &api.Restic{}
+		if err = json.Unmarshal(req.Object.Raw, restic); err == nil {
+			if restic.Namespace == "" {
+				restic.Namespace = req.Namespace
+			}
+			// Restic has no status subresource, so status updates by the operator and sidecars
+			// must not be rejected because of a change in the cluster, eg. a deleted secret.
+			old := &api.Restic{}
+			if req.Operation == admission.Update && json.Unmarshal(req.OldObject.Raw, old) == nil && util.ResticEqual(old, restic) {
+				break
+			}
+			err = s.validator.ValidateRestic(restic)
+		}
+	case api.ResourceKindRecovery:
+		rec := &api.Recovery{}
+		if err = json.Unmarshal(req.Object.Raw, rec); err == nil {
+			if rec.Namespace == "" {
+				rec.Namespace = req.Namespace
+			}
+			old := &api.Recovery{}
+			if req.Operation == admission.Update && json.Unmarshal(req.OldObject.Raw, old) == nil && util.RecoveryEqual(old, rec) {
+				break
+			}
+			err = s.validator.ValidateRecovery(rec)
+		}
+	case api.ResourceKindBackupSession:
+		// sidecars update status of a session after its Restic may have changed, so only new sessions are checked.
+		if req.Operation != admission.Create {
+			break
+		}
+		session := &api.BackupSession{}
+		if err = json.Unmarshal(req.Object.Raw, session); err == nil {
+			if session.Namespace == "" {
+				session.Namespace = req.Namespace
+			}
+			err = s.validator.ValidateBackupSession(session)
+		}
+	}
+	if err != nil {
+		resp.Allowed = false
+		resp.Result = &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Reason:  metav1.StatusReasonInvalid,
+			Message: err.Error(),
+			Code:    http.StatusUnprocessableEntity,
+		}
+	}
+	return resp
+}
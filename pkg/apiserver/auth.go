@@ -0,0 +1,162 @@
+package apiserver
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	rapi "github.com/appscode/stash/apis/repositories/v1alpha1"
+	authz "k8s.io/api/authorization/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	authenticationConfigMapNamespace = "kube-system"
+	authenticationConfigMapName      = "extension-apiserver-authentication"
+)
+
+type userInfo struct {
+	name   string
+	groups []string
+	extra  map[string]authz.ExtraValue
+}
+
+// requestHeaderAuthenticator authenticates requests proxied by kube-aggregator. The
+// aggregator presents a client certificate signed by the request header CA and passes
+// the user information in request headers.
+type requestHeaderAuthenticator struct {
+	clientCA            *x509.CertPool
+	allowedNames        []string
+	usernameHeaders     []string
+	groupHeaders        []string
+	extraHeaderPrefixes []string
+}
+
+func newRequestHeaderAuthenticator(kubeClient kubernetes.Interface) (*requestHeaderAuthenticator, error) {
+	cm, err := kubeClient.CoreV1().ConfigMaps(authenticationConfigMapNamespace).Get(authenticationConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	caData, ok := cm.Data["requestheader-client-ca-file"]
+	if !ok {
+		return nil, fmt.Errorf("missing requestheader-client-ca-file in configmap %s/%s", cm.Namespace, cm.Name)
+	}
+	a := &requestHeaderAuthenticator{
+		clientCA: x509.NewCertPool(),
+	}
+	if !a.clientCA.AppendCertsFromPEM([]byte(caData)) {
+		return nil, fmt.Errorf("failed to parse requestheader-client-ca-file in configmap %s/%s", cm.Namespace, cm.Name)
+	}
+	for key, out := range map[string]*[]string{
+		"requestheader-allowed-names":        &a.allowedNames,
+		"requestheader-username-headers":     &a.usernameHeaders,
+		"requestheader-group-headers":        &a.groupHeaders,
+		"requestheader-extra-headers-prefix": &a.extraHeaderPrefixes,
+	} {
+		if v, ok := cm.Data[key]; ok && v != "" {
+			if err := json.Unmarshal([]byte(v), out); err != nil {
+				return nil, fmt.Errorf("failed to parse %s in configmap %s/%s, reason: %s", key, cm.Namespace, cm.Name, err)
+			}
+		}
+	}
+	return a, nil
+}
+
+func (a *requestHeaderAuthenticator) authenticate(r *http.Request) (*userInfo, error) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+		return nil, fmt.Errorf("missing client certificate")
+	}
+	if len(a.allowedNames) > 0 {
+		cn := r.TLS.VerifiedChains[0][0].Subject.CommonName
+		found := false
+		for _, name := range a.allowedNames {
+			if name == cn {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("client certificate %s is not allowed", cn)
+		}
+	}
+
+	user := &userInfo{
+		extra: map[string]authz.ExtraValue{},
+	}
+	for _, h := range a.usernameHeaders {
+		if v := r.Header.Get(h); v != "" {
+			user.name = v
+			break
+		}
+	}
+	if user.name == "" {
+		return nil, fmt.Errorf("missing username in request header")
+	}
+	for _, h := range a.groupHeaders {
+		user.groups = append(user.groups, r.Header[http.CanonicalHeaderKey(h)]...)
+	}
+	for _, prefix := range a.extraHeaderPrefixes {
+		prefix = strings.ToLower(prefix)
+		for h, values := range r.Header {
+			if strings.HasPrefix(strings.ToLower(h), prefix) {
+				key := strings.ToLower(h[len(prefix):])
+				user.extra[key] = append(user.extra[key], values...)
+			}
+		}
+	}
+	return user, nil
+}
+
+type userHandlerFunc func(w http.ResponseWriter, r *http.Request, user *userInfo)
+
+// authenticated wraps h so that it is only called for authenticated requests.
+func (s *Server) authenticated(h func(w http.ResponseWriter, r *http.Request)) http.Handler {
+	return s.withUser(func(w http.ResponseWriter, r *http.Request, _ *userInfo) {
+		h(w, r)
+	})
+}
+
+func (s *Server) withUser(h userHandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.authn == nil {
+			writeError(w, kerr.NewUnauthorized("authentication is not configured"))
+			return
+		}
+		user, err := s.authn.authenticate(r)
+		if err != nil {
+			writeError(w, kerr.NewUnauthorized(err.Error()))
+			return
+		}
+		h(w, r, user)
+	})
+}
+
+// authorize checks whether user is allowed to perform verb on snapshots using SubjectAccessReview.
+func (s *Server) authorize(user *userInfo, verb, namespace, name string) error {
+	review, err := s.kubeClient.AuthorizationV1().SubjectAccessReviews().Create(&authz.SubjectAccessReview{
+		Spec: authz.SubjectAccessReviewSpec{
+			ResourceAttributes: &authz.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      verb,
+				Group:     rapi.SchemeGroupVersion.Group,
+				Version:   rapi.SchemeGroupVersion.Version,
+				Resource:  rapi.ResourceTypeSnapshot,
+				Name:      name,
+			},
+			User:   user.name,
+			Groups: user.groups,
+			Extra:  user.extra,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if !review.Status.Allowed {
+		return kerr.NewForbidden(rapi.Resource(rapi.ResourceTypeSnapshot), name, fmt.Errorf("%s", review.Status.Reason))
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package apiserver
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/appscode/go/log"
+	"github.com/appscode/pat"
+	"github.com/appscode/stash/apis/repositories"
+	rapi "github.com/appscode/stash/apis/repositories/v1alpha1"
+	cs "github.com/appscode/stash/client/typed/stash/v1alpha1"
+	"github.com/appscode/stash/pkg/admission"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/cert"
+)
+
+const (
+	PathParamNamespace = ":namespace"
+	PathParamName      = ":name"
+)
+
+// Server serves the read-only repositories.stash.appscode.com API group. It is
+// registered with kube-apiserver using an APIService and only accepts requests
+// proxied by kube-aggregator. It also serves the admission webhooks of Stash.
+type Server struct {
+	kubeClient     kubernetes.Interface
+	stashClient    cs.StashV1alpha1Interface
+	scratchDir     string
+	authn          *requestHeaderAuthenticator
+	validator      *admission.Validator
+	mutator        admission.WorkloadMutator
+	enableWebhooks bool
+	// service used by APIService and webhooks to reach the operator
+	serviceName      string
+	serviceNamespace string
+}
+
+func New(kubeClient kubernetes.Interface, stashClient cs.StashV1alpha1Interface, mutator admission.WorkloadMutator, scratchDir string, enableWebhooks bool, serviceName, serviceNamespace string) *Server {
+	return &Server{
+		kubeClient:       kubeClient,
+		stashClient:      stashClient,
+		scratchDir:       scratchDir,
+		validator:        admission.NewValidator(kubeClient, stashClient),
+		mutator:          mutator,
+		enableWebhooks:   enableWebhooks,
+		serviceName:      serviceName,
+		serviceNamespace: serviceNamespace,
+	}
+}
+
+// servingHost is used as CN of the self-signed serving certificate.
+func (s *Server) servingHost() string {
+	return fmt.Sprintf("%s.%s.svc", s.serviceName, s.serviceNamespace)
+}
+
+func (s *Server) Handler() http.Handler {
+	gv := rapi.SchemeGroupVersion
+	m := pat.New()
+	m.Get("/healthz", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	m.Get("/apis", s.authenticated(s.serveGroupList))
+	m.Get(fmt.Sprintf("/apis/%s", gv.Group), s.authenticated(s.serveGroup))
+	m.Get(fmt.Sprintf("/apis/%s", gv.String()), s.authenticated(s.serveResourceList))
+	m.Get(fmt.Sprintf("/apis/%s/%s", gv.String(), rapi.ResourceTypeSnapshot), s.withUser(s.listSnapshots))
+	m.Get(fmt.Sprintf("/apis/%s/namespaces/%s/%s", gv.String(), PathParamNamespace, rapi.ResourceTypeSnapshot), s.withUser(s.listSnapshots))
+	m.Get(fmt.Sprintf("/apis/%s/namespaces/%s/%s/%s", gv.String(), PathParamNamespace, rapi.ResourceTypeSnapshot, PathParamName), s.withUser(s.getSnapshot))
+	m.Post(admission.ValidatingWebhookPath, http.HandlerFunc(s.validate))
+	m.Post(admission.MutatingWebhookPath, http.HandlerFunc(s.mutate))
+	return m
+}
+
+// ListenAndServeTLS serves the API on address. If certFile and keyFile are empty,
+// a self-signed certificate is used. In that case, APIService must skip TLS verification.
+// If webhooks are enabled, they are registered trusting certFile or the self-signed certificate,
+// so certFile must include the CA that signed it.
+func (s *Server) ListenAndServeTLS(address, certFile, keyFile string) error {
+	authn, err := newRequestHeaderAuthenticator(s.kubeClient)
+	if err != nil {
+		return err
+	}
+	s.authn = authn
+
+	var certPEM, keyPEM []byte
+	if certFile != "" && keyFile != "" {
+		if certPEM, err = ioutil.ReadFile(certFile); err != nil {
+			return err
+		}
+		keyPEM, err = ioutil.ReadFile(keyFile)
+	} else {
+		certPEM, keyPEM, err = cert.GenerateSelfSignedCertKey(s.servingHost(), nil, nil)
+	}
+	if err != nil {
+		return err
+	}
+	serving, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return err
+	}
+
+	if s.enableWebhooks {
+		// Webhooks are optional, Restic and Recovery are still validated and sidecars injected by the controller.
+		if err := admission.EnsureValidatingWebhookConfiguration(s.kubeClient, s.serviceName, s.serviceNamespace, certPEM); err != nil {
+			log.Errorf("Failed to register validating webhook, reason: %s", err)
+		}
+		if err := admission.EnsureMutatingWebhookConfiguration(s.kubeClient, s.serviceName, s.serviceNamespace, certPEM); err != nil {
+			log.Errorf("Failed to register mutating webhook, reason: %s", err)
+		}
+	}
+
+	srv := &http.Server{
+		Addr:    address,
+		Handler: s.Handler(),
+		TLSConfig: &tls.Config{
+			Certificates: []tls.Certificate{serving},
+			ClientAuth:   tls.VerifyClientCertIfGiven,
+			ClientCAs:    authn.clientCA,
+			MinVersion:   tls.VersionTLS12,
+		},
+	}
+	log.Infof("Serving %s API on %s", repositories.GroupName, address)
+	return srv.ListenAndServeTLS("", "")
+}
+
+func (s *Server) serveGroupList(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, &metav1.APIGroupList{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "APIGroupList",
+		},
+		Groups: []metav1.APIGroup{apiGroup()},
+	})
+}
+
+func (s *Server) serveGroup(w http.ResponseWriter, r *http.Request) {
+	group := apiGroup()
+	group.TypeMeta = metav1.TypeMeta{
+		APIVersion: "v1",
+		Kind:       "APIGroup",
+	}
+	writeJSON(w, http.StatusOK, &group)
+}
+
+func (s *Server) serveResourceList(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, &metav1.APIResourceList{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "APIResourceList",
+		},
+		GroupVersion: rapi.SchemeGroupVersion.String(),
+		APIResources: []metav1.APIResource{
+			{
+				Name:         rapi.ResourceTypeSnapshot,
+				SingularName: rapi.ResourceNameSnapshot,
+				Namespaced:   true,
+				Kind:         rapi.ResourceKindSnapshot,
+				Verbs:        metav1.Verbs{"get", "list"},
+			},
+		},
+	})
+}
+
+func apiGroup() metav1.APIGroup {
+	gv := metav1.GroupVersionForDiscovery{
+		GroupVersion: rapi.SchemeGroupVersion.String(),
+		Version:      rapi.SchemeGroupVersion.Version,
+	}
+	return metav1.APIGroup{
+		Name:             repositories.GroupName,
+		Versions:         []metav1.GroupVersionForDiscovery{gv},
+		PreferredVersion: gv,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, obj interface{}) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(data)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status, ok := err.(kerr.APIStatus)
+	if !ok {
+		status = kerr.NewInternalError(err)
+	}
+	st := status.Status()
+	st.TypeMeta = metav1.TypeMeta{
+		APIVersion: "v1",
+		Kind:       "Status",
+	}
+	writeJSON(w, int(st.Code), &st)
+}
@@ -0,0 +1,265 @@
+package apiserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/appscode/go/log"
+	"github.com/appscode/pat"
+	rapi "github.com/appscode/stash/apis/repositories/v1alpha1"
+	api "github.com/appscode/stash/apis/stash/v1alpha1"
+	"github.com/appscode/stash/pkg/cli"
+	"github.com/appscode/stash/pkg/util"
+	"github.com/golang/glog"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// snapshotListTimeout bounds the time restic may take to list the snapshots of a repository.
+	snapshotListTimeout = 30 * time.Second
+	// maxParallelSnapshotLists is the maximum number of restic processes listing snapshots for a request.
+	maxParallelSnapshotLists = 8
+)
+
+func (s *Server) listSnapshots(w http.ResponseWriter, r *http.Request, user *userInfo) {
+	var namespace string
+	if params, found := pat.FromContext(r.Context()); found {
+		namespace = params.Get(PathParamNamespace)
+	}
+	if r.URL.Query().Get("watch") == "true" {
+		writeError(w, kerr.NewMethodNotSupported(rapi.Resource(rapi.ResourceTypeSnapshot), "watch"))
+		return
+	}
+	if err := s.authorize(user, "list", namespace, ""); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	labelSelector, err := labels.Parse(r.URL.Query().Get("labelSelector"))
+	if err != nil {
+		writeError(w, kerr.NewBadRequest(err.Error()))
+		return
+	}
+	fieldSelector, err := fields.ParseSelector(r.URL.Query().Get("fieldSelector"))
+	if err != nil {
+		writeError(w, kerr.NewBadRequest(err.Error()))
+		return
+	}
+	for _, req := range fieldSelector.Requirements() {
+		if _, ok := snapshotFields[req.Field]; !ok {
+			writeError(w, kerr.NewBadRequest(fmt.Sprintf("field label %q is not supported for %s", req.Field, rapi.ResourceTypeSnapshot)))
+			return
+		}
+	}
+
+	repos, err := s.stashClient.Repositories(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	snapshots := s.snapshots(r.Context(), repos.Items)
+	result := &rapi.SnapshotList{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: rapi.SchemeGroupVersion.String(),
+			Kind:       rapi.ResourceKindSnapshotList,
+		},
+		Items: make([]rapi.Snapshot, 0, len(snapshots)),
+	}
+	for _, snapshot := range snapshots {
+		if labelSelector.Matches(labels.Set(snapshot.Labels)) && matchesFields(snapshot, fieldSelector) {
+			result.Items = append(result.Items, snapshot)
+		}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) getSnapshot(w http.ResponseWriter, r *http.Request, user *userInfo) {
+	params, found := pat.FromContext(r.Context())
+	if !found {
+		writeError(w, kerr.NewBadRequest("missing parameters"))
+		return
+	}
+	namespace := params.Get(PathParamNamespace)
+	name := params.Get(PathParamName)
+	if err := s.authorize(user, "get", namespace, name); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	// snapshots are named <repository>-<short id>, and the short id contains no "-"
+	idx := strings.LastIndex(name, "-")
+	if idx <= 0 {
+		writeError(w, kerr.NewNotFound(rapi.Resource(rapi.ResourceTypeSnapshot), name))
+		return
+	}
+	repo, err := s.stashClient.Repositories(namespace).Get(name[:idx], metav1.GetOptions{})
+	if kerr.IsNotFound(err) {
+		writeError(w, kerr.NewNotFound(rapi.Resource(rapi.ResourceTypeSnapshot), name))
+		return
+	} else if err != nil {
+		writeError(w, err)
+		return
+	}
+	snapshots, err := s.repositorySnapshots(r.Context(), repo)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.Name == name {
+			writeJSON(w, http.StatusOK, &snapshot)
+			return
+		}
+	}
+	writeError(w, kerr.NewNotFound(rapi.Resource(rapi.ResourceTypeSnapshot), name))
+}
+
+// snapshots lists snapshots of repos by running restic for each repository in parallel.
+// Repositories whose snapshots can't be listed are logged and skipped, so that a broken secret
+// or an unavailable backend does not fail the whole list.
+func (s *Server) snapshots(ctx context.Context, repos []api.Repository) []rapi.Snapshot {
+	results := make([][]rapi.Snapshot, len(repos))
+	slots := make(chan struct{}, maxParallelSnapshotLists)
+	var wg sync.WaitGroup
+	for i := range repos {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			repo := &repos[i]
+			snapshots, err := s.repositorySnapshots(ctx, repo)
+			if err != nil {
+				log.Errorf("Failed to list snapshots of Repository %s/%s, reason: %s", repo.Namespace, repo.Name, err)
+				return
+			}
+			results[i] = snapshots
+		}(i)
+	}
+	wg.Wait()
+
+	result := make([]rapi.Snapshot, 0)
+	for _, snapshots := range results {
+		result = append(result, snapshots...)
+	}
+	return result
+}
+
+// repositorySnapshots lists snapshots of repo by running restic, which is killed after
+// snapshotListTimeout. Repositories in local backends have no snapshots here, since these
+// volumes are only mounted inside workload pods.
+func (s *Server) repositorySnapshots(ctx context.Context, repo *api.Repository) ([]rapi.Snapshot, error) {
+	if repo.Spec.Backend.Local != nil || repo.Spec.Backend.StorageSecretName == "" {
+		glog.V(3).Infof("Skipping snapshots of Repository %s/%s in local backend", repo.Namespace, repo.Name)
+		return nil, nil
+	}
+	secret, err := s.kubeClient.CoreV1().Secrets(repo.Namespace).Get(repo.Spec.Backend.StorageSecretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	scratchDir := filepath.Join(s.scratchDir, "snapshots", repo.Namespace, repo.Name)
+	if err = os.MkdirAll(scratchDir, 0755); err != nil {
+		return nil, err
+	}
+	resticCLI := cli.New(scratchDir, true, repo.Spec.Hostname)
+	if err = resticCLI.SetupEnv(repo.Spec.Backend, secret, repo.Spec.Prefix); err != nil {
+		return nil, err
+	}
+	resticCLI.SetBandwidthLimit(&util.DefaultBandwidthLimit)
+
+	ctx, cancel := context.WithTimeout(ctx, snapshotListTimeout)
+	defer cancel()
+	snapshots, err := resticCLI.WithContext(ctx).ListSnapshots()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]rapi.Snapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		result = append(result, toSnapshot(repo, snapshot))
+	}
+	return result, nil
+}
+
+func toSnapshot(repo *api.Repository, in cli.Snapshot) rapi.Snapshot {
+	id := in.ID
+	if len(id) > 8 {
+		id = id[:8]
+	}
+	out := rapi.Snapshot{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: rapi.SchemeGroupVersion.String(),
+			Kind:       rapi.ResourceKindSnapshot,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              repo.Name + "-" + id,
+			Namespace:         repo.Namespace,
+			CreationTimestamp: metav1.NewTime(in.Time),
+			Labels: map[string]string{
+				rapi.LabelRepository:   repo.Name,
+				rapi.LabelRestic:       repo.Spec.Restic,
+				rapi.LabelWorkloadKind: repo.Spec.Workload.Kind,
+				rapi.LabelWorkloadName: repo.Spec.Workload.Name,
+			},
+		},
+		Status: rapi.SnapshotStatus{
+			ID:         in.ID,
+			Tree:       in.Tree,
+			Paths:      in.Paths,
+			Hostname:   in.Hostname,
+			Username:   in.Username,
+			UID:        in.UID,
+			Gid:        in.Gid,
+			Tags:       in.Tags,
+			Repository: repo.Spec.Prefix,
+		},
+	}
+	if len(validation.IsValidLabelValue(in.Hostname)) == 0 {
+		out.Labels[rapi.LabelHostname] = in.Hostname
+	}
+	return out
+}
+
+// snapshotFields are the fields supported in field selectors. Each returns the values
+// of the field. A requirement matches if any of the values match.
+var snapshotFields = map[string]func(rapi.Snapshot) []string{
+	"metadata.name":      func(s rapi.Snapshot) []string { return []string{s.Name} },
+	"metadata.namespace": func(s rapi.Snapshot) []string { return []string{s.Namespace} },
+	"status.hostname":    func(s rapi.Snapshot) []string { return []string{s.Status.Hostname} },
+	"status.paths":       func(s rapi.Snapshot) []string { return s.Status.Paths },
+	"status.tags":        func(s rapi.Snapshot) []string { return s.Status.Tags },
+}
+
+func matchesFields(snapshot rapi.Snapshot, sel fields.Selector) bool {
+	for _, req := range sel.Requirements() {
+		found := false
+		for _, v := range snapshotFields[req.Field](snapshot) {
+			if v == req.Value {
+				found = true
+				break
+			}
+		}
+		switch req.Operator {
+		case selection.Equals, selection.DoubleEquals:
+			if !found {
+				return false
+			}
+		case selection.NotEquals:
+			if found {
+				return false
+			}
+		}
+	}
+	return true
+}
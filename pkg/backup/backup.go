@@ -1,33 +1,32 @@
 package backup
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
+	"os/exec"
+	"strings"
 	"time"
 
 	"github.com/appscode/go/log"
-	core_util "github.com/appscode/kutil/core/v1"
-	rbac_util "github.com/appscode/kutil/rbac/v1beta1"
 	api "github.com/appscode/stash/apis/stash/v1alpha1"
 	cs "github.com/appscode/stash/client/typed/stash/v1alpha1"
 	stash_util "github.com/appscode/stash/client/typed/stash/v1alpha1/util"
 	stash_listers "github.com/appscode/stash/listers/stash/v1alpha1"
 	"github.com/appscode/stash/pkg/cli"
-	"github.com/appscode/stash/pkg/controller"
 	"github.com/appscode/stash/pkg/eventer"
 	"github.com/appscode/stash/pkg/util"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/push"
 	"gopkg.in/robfig/cron.v2"
 	core "k8s.io/api/core/v1"
-	rbac "k8s.io/api/rbac/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
-	"k8s.io/client-go/tools/reference"
 	"k8s.io/client-go/util/workqueue"
 )
 
@@ -45,41 +44,67 @@ type Options struct {
 	ResyncPeriod     time.Duration
 	MaxNumRequeues   int
 	RunViaCron       bool
-	ImageTag         string // image tag for check job
-	EnableRBAC       bool   // rbac for check job
+	ImageTag         string // unused, check jobs are replaced by maintenance jobs
+	EnableRBAC       bool   // unused, check jobs are replaced by maintenance jobs
 }
 
 type Controller struct {
-	k8sClient   kubernetes.Interface
-	stashClient cs.StashV1alpha1Interface
-	opt         Options
-	locked      chan struct{}
-	resticCLI   *cli.ResticWrapper
-	cron        *cron.Cron
-	recorder    record.EventRecorder
+	k8sClient    kubernetes.Interface
+	stashClient  cs.StashV1alpha1Interface
+	clientConfig *rest.Config
+	opt          Options
+	locked       chan struct{}
+	resticCLI    *cli.ResticWrapper
+	cron         *cron.Cron
+	recorder     record.EventRecorder
+	startTime    time.Time
+	// stopCh is closed when the backup is stopped, nil if it runs until the process exits
+	stopCh <-chan struct{}
+	// semaphore limits concurrent backups in the namespace, nil if unlimited
+	semaphore *semaphore
 
 	// Restic
 	rQueue    workqueue.RateLimitingInterface
 	rIndexer  cache.Indexer
 	rInformer cache.Controller
 	rLister   stash_listers.ResticLister
+
+	// BackupSession
+	bsQueue    workqueue.RateLimitingInterface
+	bsIndexer  cache.Indexer
+	bsInformer cache.Controller
+	// bsResults holds results of finished BackupSessions whose status update failed, so that only
+	// the update is retried. It is only accessed by the single BackupSession worker.
+	bsResults map[string]api.HostBackupStatus
 }
 
 const (
-	CheckRole            = "stash-check"
 	BackupEventComponent = "stash-backup"
 )
 
-func New(k8sClient kubernetes.Interface, stashClient cs.StashV1alpha1Interface, opt Options) *Controller {
-	return &Controller{
-		k8sClient:   k8sClient,
-		stashClient: stashClient,
-		opt:         opt,
-		cron:        cron.New(),
-		locked:      make(chan struct{}, 1),
-		resticCLI:   cli.New(opt.ScratchDir, true, opt.SnapshotHostname),
-		recorder:    eventer.NewEventRecorder(k8sClient, BackupEventComponent),
+func New(config *rest.Config, k8sClient kubernetes.Interface, stashClient cs.StashV1alpha1Interface, opt Options) *Controller {
+	c := &Controller{
+		k8sClient:    k8sClient,
+		stashClient:  stashClient,
+		clientConfig: config,
+		opt:          opt,
+		cron:         cron.New(),
+		locked:       make(chan struct{}, 1),
+		resticCLI:    cli.New(opt.ScratchDir, true, opt.SnapshotHostname),
+		recorder:     eventer.NewEventRecorder(k8sClient, BackupEventComponent),
+		startTime:    time.Now(),
+		bsResults:    map[string]api.HostBackupStatus{},
+	}
+	c.resticCLI.SetStaleLockHandler(&staleLockHandler{c: c})
+	if util.MaxConcurrentBackups > 0 {
+		c.semaphore = &semaphore{
+			client:    k8sClient,
+			namespace: opt.Namespace,
+			identity:  opt.PodName,
+			limit:     util.MaxConcurrentBackups,
+		}
 	}
+	return c
 }
 
 func (c *Controller) Backup() error {
@@ -88,7 +113,15 @@ func (c *Controller) Backup() error {
 		return fmt.Errorf("failed to setup backup: %s", err)
 	}
 
-	if err := c.runResticBackup(resource); err != nil {
+	err = c.runWithRetry(resource, func() error {
+		return c.withTimeout(resource, func(ctx context.Context) error {
+			return c.withBackupSlot(ctx, func() error {
+				_, err := c.runResticBackup(ctx, resource)
+				return err
+			})
+		})
+	})
+	if err != nil {
 		eventer.CreateEventWithLog(
 			c.k8sClient,
 			BackupEventComponent,
@@ -100,44 +133,7 @@ func (c *Controller) Backup() error {
 		return fmt.Errorf("failed to run backup, reason: %s", err)
 	}
 
-	// create check job
-	job := util.NewCheckJob(resource, c.opt.SnapshotHostname, c.opt.SmartPrefix, c.opt.ImageTag)
-	if c.opt.EnableRBAC {
-		job.Spec.Template.Spec.ServiceAccountName = job.Name
-	}
-	if job, err = c.k8sClient.BatchV1().Jobs(resource.Namespace).Create(job); err != nil {
-		err = fmt.Errorf("failed to create check job, reason: %s", err)
-		eventer.CreateEventWithLog(
-			c.k8sClient,
-			BackupEventComponent,
-			resource.ObjectReference(),
-			core.EventTypeWarning,
-			eventer.EventReasonFailedCronJob,
-			err.Error(),
-		)
-		return err
-	}
-
-	// create service-account and role-binding
-	if c.opt.EnableRBAC {
-		ref, err := reference.GetReference(scheme.Scheme, job)
-		if err != nil {
-			return err
-		}
-		if err = c.ensureCheckRBAC(ref); err != nil {
-			return fmt.Errorf("error ensuring rbac for check job %s, reason: %s\n", job.Name, err)
-		}
-	}
-
-	log.Infoln("Created check job:", job.Name)
-	eventer.CreateEventWithLog(
-		c.k8sClient,
-		BackupEventComponent,
-		resource.ObjectReference(),
-		core.EventTypeNormal,
-		eventer.EventReasonCheckJobCreated,
-		fmt.Sprintf("Created check job: %s", job.Name),
-	)
+	// the repository is checked by the maintenance jobs scheduled by the operator
 	return nil
 }
 
@@ -157,6 +153,7 @@ func (c *Controller) setup() (*api.Restic, error) {
 		return nil, err
 	}
 	log.Infof("Found restic %s\n", resource.Name)
+	resource = resource.Resolved()
 	if err := resource.IsValid(); err != nil {
 		return nil, err
 	}
@@ -170,6 +167,7 @@ func (c *Controller) setup() (*api.Restic, error) {
 	if err = c.resticCLI.SetupEnv(resource.Spec.Backend, secret, c.opt.SmartPrefix); err != nil {
 		return nil, err
 	}
+	c.resticCLI.SetBandwidthLimit(resource.Spec.Bandwidth, &util.DefaultBandwidthLimit)
 	if err = c.resticCLI.InitRepositoryIfAbsent(); err != nil {
 		return nil, err
 	}
@@ -177,8 +175,13 @@ func (c *Controller) setup() (*api.Restic, error) {
 	return resource, nil
 }
 
-func (c *Controller) runResticBackup(resource *api.Restic) (err error) {
+// runResticBackup backs up fileGroups of resource and returns the result of each fileGroup it has processed.
+// With the FailFast policy, fileGroups not started before the first failure are skipped. With ContinueAll,
+// every fileGroup is processed and the returned error lists all failures.
+func (c *Controller) runResticBackup(ctx context.Context, resource *api.Restic) (fgStats []api.FileGroupBackupStatus, err error) {
 	startTime := metav1.Now()
+	// restic commands of the backup are killed when ctx is done
+	resticCLI := c.resticCLI.WithContext(ctx)
 	var (
 		restic_session_success = prometheus.NewGauge(prometheus.GaugeOpts{
 			Namespace: "restic",
@@ -204,6 +207,7 @@ func (c *Controller) runResticBackup(resource *api.Restic) (err error) {
 			Name:      "duration_seconds",
 			Help:      "Total seconds taken to complete restic session",
 		}, []string{"filegroup", "op"})
+		backupMetrics = newBackupMetrics()
 	)
 
 	defer func() {
@@ -218,13 +222,28 @@ func (c *Controller) runResticBackup(resource *api.Restic) (err error) {
 			}
 			restic_session_duration_seconds_total.Set(endTime.Sub(startTime.Time).Seconds())
 
-			push.Collectors(c.JobName(resource),
-				c.GroupingKeys(resource),
-				c.opt.PushgatewayURL,
+			collectors := []prometheus.Collector{
 				restic_session_success,
 				restic_session_fail,
 				restic_session_duration_seconds_total,
-				restic_session_duration_seconds)
+				restic_session_duration_seconds,
+			}
+			push.Collectors(c.JobName(resource),
+				c.GroupingKeys(resource),
+				c.opt.PushgatewayURL,
+				append(collectors, backupMetrics.collectors()...)...)
+		}
+
+		record := api.HostBackupStatus{
+			Hostname:   c.opt.SnapshotHostname,
+			Phase:      api.BackupSessionSucceeded,
+			StartTime:  &startTime,
+			EndTime:    &endTime,
+			FileGroups: fgStats,
+		}
+		if err != nil {
+			record.Phase = api.BackupSessionFailed
+			record.Error = err.Error()
 		}
 
 		stash_util.PatchRestic(c.stashClient, resource, func(in *api.Restic) *api.Restic {
@@ -233,106 +252,229 @@ func (c *Controller) runResticBackup(resource *api.Restic) (err error) {
 			if in.Status.FirstBackupTime == nil {
 				in.Status.FirstBackupTime = &startTime
 			}
+			if err == nil {
+				in.Status.LastSuccessfulBackupTime = &startTime
+			}
 			in.Status.LastBackupDuration = endTime.Sub(startTime.Time).String()
 			return in
 		})
+		c.ensureRepository(resticCLI, resource, record)
 	}()
 
-	for _, fg := range resource.Spec.FileGroups {
+	if resource.Spec.PostBackup != nil {
+		// registered before running preBackup, so that it runs even if preBackup fails
+		defer func() {
+			if e := c.runHook(resource, HookPostBackup, resource.Spec.PostBackup); e != nil && err == nil {
+				err = e
+			}
+		}()
+	}
+	if resource.Spec.PreBackup != nil {
+		if err = c.runHook(resource, HookPreBackup, resource.Spec.PreBackup); err != nil {
+			return
+		}
+	}
+
+	// all fileGroups are backed up before forgetting old snapshots, as forget locks the
+	// repository exclusively and would fail backups running in parallel
+	failFast := resource.Spec.FailurePolicy != api.ContinueAll
+	results := c.backupFileGroups(resource, failFast, func(fg api.FileGroup) (summary *cli.BackupSummary, e error) {
 		backupOpMetric := restic_session_duration_seconds.WithLabelValues(sanitizeLabelValue(fg.Path), "backup")
-		err = c.measure(c.resticCLI.Backup, resource, fg, backupOpMetric)
-		if err != nil {
-			log.Errorf("Backup operation failed for Restic %s/%s due to %s\n", resource.Namespace, resource.Name, err)
+		e = c.measure(func(resource *api.Restic, fg api.FileGroup) (e error) {
+			summary, e = c.backupFileGroup(resticCLI, resource, fg)
+			return
+		}, resource, fg, backupOpMetric)
+		if e != nil {
+			log.Errorf("Backup operation failed for Restic %s/%s due to %s\n", resource.Namespace, resource.Name, e)
 			eventer.CreateEventWithLog(
 				c.k8sClient,
 				BackupEventComponent,
 				resource.ObjectReference(),
 				core.EventTypeWarning,
 				eventer.EventReasonFailedToBackup,
-				fmt.Sprintf("Backup operation failed for Restic %s/%s due to %s", resource.Namespace, resource.Name, err),
+				fmt.Sprintf("Backup operation failed for Restic %s/%s, path: %s due to %s", resource.Namespace, resource.Name, fg.Path, e),
 			)
 			return
-		} else {
-			hostname, _ := os.Hostname()
-			eventer.CreateEventWithLog(
-				c.k8sClient,
-				BackupEventComponent,
-				resource.ObjectReference(),
-				core.EventTypeNormal,
-				eventer.EventReasonSuccessfulBackup,
-				fmt.Sprintf("Backed up pod: %s, path: %s", hostname, fg.Path),
-			)
+		}
+		backupMetrics.set(fg.Path, summary)
+		hostname, _ := os.Hostname()
+		msg := fmt.Sprintf("Backed up pod: %s, path: %s", hostname, fg.Path)
+		if summary != nil {
+			msg += ", snapshot: " + summary.SnapshotID
+		}
+		eventer.CreateEventWithLog(
+			c.k8sClient,
+			BackupEventComponent,
+			resource.ObjectReference(),
+			core.EventTypeNormal,
+			eventer.EventReasonSuccessfulBackup,
+			msg,
+		)
+		return
+	})
+
+	var (
+		errs         []string
+		forgetFailed bool
+	)
+	for i, fg := range resource.Spec.FileGroups {
+		result := results[i]
+		if result == nil {
+			// skipped after a failure
+			continue
+		}
+		if result.err != nil {
+			fgStats = append(fgStats, fileGroupFailed(fg, result.duration, result.err))
+			errs = append(errs, fmt.Sprintf("%s: %s", fg.Path, result.err))
+			if err == nil {
+				err = result.err
+			}
+			continue
+		}
+		if failFast && forgetFailed {
+			fgStats = append(fgStats, fileGroupStatus(fg, result.duration, result.summary))
+			continue
 		}
 
+		forgetStartTime := time.Now()
 		forgetOpMetric := restic_session_duration_seconds.WithLabelValues(sanitizeLabelValue(fg.Path), "forget")
-		err = c.measure(c.resticCLI.Forget, resource, fg, forgetOpMetric)
-		if err != nil {
-			log.Errorf("Failed to forget old snapshots for Restic %s/%s due to %s\n", resource.Namespace, resource.Name, err)
+		e := c.measure(resticCLI.Forget, resource, fg, forgetOpMetric)
+		result.duration += time.Since(forgetStartTime)
+		if e != nil {
+			fgStat := fileGroupStatus(fg, result.duration, result.summary)
+			fgStat.Phase = api.BackupSessionFailed
+			fgStat.Error = fmt.Sprintf("failed to forget old snapshots, reason: %s", e)
+			fgStats = append(fgStats, fgStat)
+			log.Errorf("Failed to forget old snapshots for Restic %s/%s due to %s\n", resource.Namespace, resource.Name, e)
 			eventer.CreateEventWithLog(
 				c.k8sClient,
 				BackupEventComponent,
 				resource.ObjectReference(),
 				core.EventTypeWarning,
 				eventer.EventReasonFailedToRetention,
-				fmt.Sprintf("Failed to forget old snapshots for Restic %s/%s due to %s", resource.Namespace, resource.Name, err),
+				fmt.Sprintf("Failed to forget old snapshots for Restic %s/%s due to %s", resource.Namespace, resource.Name, e),
 			)
-			return
+			errs = append(errs, fmt.Sprintf("%s: %s", fg.Path, fgStat.Error))
+			forgetFailed = true
+			if err == nil {
+				err = e
+			}
+			continue
 		}
+		fgStats = append(fgStats, fileGroupStatus(fg, result.duration, result.summary))
+	}
+	if !failFast && len(errs) > 0 {
+		err = fmt.Errorf("%d of %d fileGroups failed: %s", len(errs), len(resource.Spec.FileGroups), strings.Join(errs, "; "))
 	}
 	return
 }
 
-func (c *Controller) measure(f func(*api.Restic, api.FileGroup) error, resource *api.Restic, fg api.FileGroup, g prometheus.Gauge) (err error) {
-	startTime := time.Now()
-	defer func() {
-		g.Set(time.Now().Sub(startTime).Seconds())
-	}()
-	err = f(resource, fg)
-	return
+// fileGroupStatus returns the status of a fileGroup backed up successfully, with the summary printed by restic.
+func fileGroupStatus(fg api.FileGroup, duration time.Duration, summary *cli.BackupSummary) api.FileGroupBackupStatus {
+	status := api.FileGroupBackupStatus{
+		Path:     fg.Path,
+		Phase:    api.BackupSessionSucceeded,
+		Duration: duration.String(),
+	}
+	if summary != nil {
+		status.SnapshotID = summary.SnapshotID
+		status.DataAdded = summary.DataAdded
+		status.TotalBytesProcessed = summary.TotalBytesProcessed
+		status.FilesNew = summary.FilesNew
+		status.FilesChanged = summary.FilesChanged
+		status.FilesUnmodified = summary.FilesUnmodified
+	}
+	return status
 }
 
-// use sidecar-cluster-role, service-account and role-binding name same as job name
-// set job as owner of service-account and role-binding
-func (c *Controller) ensureCheckRBAC(resource *core.ObjectReference) error {
-	// ensure service account
-	meta := metav1.ObjectMeta{
-		Name:      resource.Name,
-		Namespace: resource.Namespace,
+func fileGroupFailed(fg api.FileGroup, duration time.Duration, err error) api.FileGroupBackupStatus {
+	return api.FileGroupBackupStatus{
+		Path:     fg.Path,
+		Phase:    api.BackupSessionFailed,
+		Duration: duration.String(),
+		Error:    err.Error(),
+	}
+}
+
+func (c *Controller) backupFileGroup(resticCLI *cli.ResticWrapper, resource *api.Restic, fg api.FileGroup) (*cli.BackupSummary, error) {
+	if fg.Stdin == nil {
+		return resticCLI.Backup(resource, fg)
 	}
-	_, _, err := core_util.CreateOrPatchServiceAccount(c.k8sClient, meta, func(in *core.ServiceAccount) *core.ServiceAccount {
-		in.ObjectMeta = util.EnsureOwnerReference(in.ObjectMeta, resource)
-		if in.Labels == nil {
-			in.Labels = map[string]string{}
+	return resticCLI.BackupFromStdin(fg, func(ctx context.Context, w io.Writer) error {
+		if fg.Stdin.Container == "" {
+			cmd := exec.CommandContext(ctx, fg.Stdin.Command[0], fg.Stdin.Command[1:]...)
+			cmd.Stdout = w
+			cmd.Stderr = os.Stderr
+			return cmd.Run()
 		}
-		in.Labels["app"] = "stash"
-		return in
+		return util.ExecIntoPodStreamContext(ctx, c.clientConfig, c.k8sClient, c.opt.Namespace, c.opt.PodName, fg.Stdin.Container, fg.Stdin.Command, w, os.Stderr)
 	})
-	if err != nil {
-		return err
-	}
-
-	// ensure role binding
-	_, _, err = rbac_util.CreateOrPatchRoleBinding(c.k8sClient, meta, func(in *rbac.RoleBinding) *rbac.RoleBinding {
-		in.ObjectMeta = util.EnsureOwnerReference(in.ObjectMeta, resource)
+}
 
-		if in.Labels == nil {
-			in.Labels = map[string]string{}
+// ensureRepository creates or updates the Repository object for the repository used by this sidecar
+// and adds record to its backup history. Snapshot count and size are only refreshed after a successful
+// backup, using resticCLI of the backup so that they are bound by the same context.
+func (c *Controller) ensureRepository(resticCLI *cli.ResticWrapper, resource *api.Restic, record api.HostBackupStatus) {
+	startTime, endTime := *record.StartTime, *record.EndTime
+	var (
+		snapshots []cli.Snapshot
+		stats     *cli.RepositoryStats
+		err       error
+	)
+	if record.Phase == api.BackupSessionSucceeded {
+		if snapshots, err = resticCLI.ListSnapshots(); err != nil {
+			log.Errorf("Failed to list snapshots of repository %s, reason: %s\n", c.opt.SmartPrefix, err)
+			snapshots = nil
+		}
+		if stats, err = resticCLI.Stats(); err != nil {
+			log.Errorf("Failed to read stats of repository %s, reason: %s\n", c.opt.SmartPrefix, err)
+			stats = nil
 		}
-		in.Labels["app"] = "stash"
+	}
 
-		in.RoleRef = rbac.RoleRef{
-			APIGroup: rbac.GroupName,
-			Kind:     "ClusterRole",
-			Name:     controller.SidecarClusterRole,
+	meta := metav1.ObjectMeta{
+		Name:      api.RepositoryName(c.opt.SmartPrefix),
+		Namespace: resource.Namespace,
+	}
+	_, _, err = stash_util.CreateOrPatchRepository(c.stashClient, meta, func(in *api.Repository) *api.Repository {
+		in.Spec = api.RepositorySpec{
+			Backend:  resource.Spec.Backend,
+			Prefix:   c.opt.SmartPrefix,
+			Workload: c.opt.Workload,
+			Hostname: c.opt.SnapshotHostname,
+			Restic:   resource.Name,
+		}
+		in.Status.BackupCount++
+		in.Status.LastBackupTime = &startTime
+		if in.Status.FirstBackupTime == nil {
+			in.Status.FirstBackupTime = &startTime
+		}
+		if record.Phase == api.BackupSessionSucceeded {
+			in.Status.LastSuccessfulBackupTime = &startTime
 		}
-		in.Subjects = []rbac.Subject{
-			{
-				Kind:      "ServiceAccount",
-				Name:      meta.Name,
-				Namespace: meta.Namespace,
-			},
+		in.Status.LastBackupDuration = endTime.Sub(startTime.Time).String()
+		in.Status.History = append([]api.HostBackupStatus{record}, in.Status.History...)
+		if len(in.Status.History) > api.BackupHistoryLimit {
+			in.Status.History = in.Status.History[:api.BackupHistoryLimit]
+		}
+		if snapshots != nil {
+			in.Status.SnapshotCount = int64(len(snapshots))
+		}
+		if stats != nil {
+			in.Status.Size = stats.TotalSize
 		}
 		return in
 	})
-	return err
+	if err != nil {
+		log.Errorf("Failed to update Repository %s/%s, reason: %s\n", meta.Namespace, meta.Name, err)
+	}
+}
+
+func (c *Controller) measure(f func(*api.Restic, api.FileGroup) error, resource *api.Restic, fg api.FileGroup, g prometheus.Gauge) (err error) {
+	startTime := time.Now()
+	defer func() {
+		g.Set(time.Now().Sub(startTime).Seconds())
+	}()
+	err = f(resource, fg)
+	return
 }
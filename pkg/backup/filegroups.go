@@ -0,0 +1,107 @@
+package backup
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	api "github.com/appscode/stash/apis/stash/v1alpha1"
+	"github.com/appscode/stash/pkg/cli"
+	core "k8s.io/api/core/v1"
+)
+
+type fileGroupResult struct {
+	summary  *cli.BackupSummary
+	err      error
+	duration time.Duration
+}
+
+// backupFileGroups runs backup for the fileGroups of resource using up to spec.parallelism
+// workers and returns the result of each fileGroup, in the order of spec.fileGroups.
+// FileGroups under the same volume are backed up one after another by the same worker.
+// With failFast, fileGroups not started before a failure are skipped and their result is nil.
+func (c *Controller) backupFileGroups(resource *api.Restic, failFast bool, backup func(api.FileGroup) (*cli.BackupSummary, error)) []*fileGroupResult {
+	fileGroups := resource.Spec.FileGroups
+	results := make([]*fileGroupResult, len(fileGroups))
+
+	// group fileGroups by volume, keeping the order of spec.fileGroups
+	var groups [][]int
+	groupIndex := map[string]int{}
+	for i, fg := range fileGroups {
+		key := volumeKey(resource.Spec.VolumeMounts, fg)
+		if j, found := groupIndex[key]; found {
+			groups[j] = append(groups[j], i)
+			continue
+		}
+		groupIndex[key] = len(groups)
+		groups = append(groups, []int{i})
+	}
+
+	workers := int(resource.Spec.Parallelism)
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(groups) {
+		workers = len(groups)
+	}
+
+	var (
+		mu     sync.Mutex
+		failed bool
+		wg     sync.WaitGroup
+	)
+	queue := make(chan []int, len(groups))
+	for _, group := range groups {
+		queue <- group
+	}
+	close(queue)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for group := range queue {
+				for _, i := range group {
+					mu.Lock()
+					skip := failFast && failed
+					mu.Unlock()
+					if skip {
+						continue
+					}
+
+					startTime := time.Now()
+					result := &fileGroupResult{}
+					result.summary, result.err = backup(fileGroups[i])
+					result.duration = time.Since(startTime)
+					results[i] = result
+					if result.err != nil {
+						mu.Lock()
+						failed = true
+						mu.Unlock()
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// volumeKey returns the name of the volume containing the path of fg, ie, the volume of the
+// longest mount path above it. FileGroups read from stdin do not use a volume.
+func volumeKey(mounts []core.VolumeMount, fg api.FileGroup) string {
+	if fg.Stdin != nil {
+		return "stdin:" + fg.Path
+	}
+	key, longest := "", -1
+	for _, m := range mounts {
+		mountPath := strings.TrimSuffix(m.MountPath, "/")
+		if fg.Path != mountPath && !strings.HasPrefix(fg.Path, mountPath+"/") {
+			continue
+		}
+		if len(mountPath) > longest {
+			key, longest = m.Name, len(mountPath)
+		}
+	}
+	return key
+}
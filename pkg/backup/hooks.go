@@ -0,0 +1,150 @@
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	api "github.com/appscode/stash/apis/stash/v1alpha1"
+	"github.com/appscode/stash/pkg/eventer"
+	"github.com/appscode/stash/pkg/util"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	HookPreBackup  = "preBackup"
+	HookPostBackup = "postBackup"
+
+	defaultHookTimeout = 30 * time.Second
+	maxHookOutput      = 1024
+)
+
+// runHook executes hook and records the result as an event of Restic. Failure is
+// returned as error unless hook.OnFailure is Ignore. A timed out exec hook always fails,
+// since the command can't be killed and may still be running while the backup is taken.
+func (c *Controller) runHook(resource *api.Restic, name string, hook *api.BackupHook) error {
+	timeout := defaultHookTimeout
+	if hook.Timeout != nil {
+		timeout = hook.Timeout.Duration
+	}
+
+	var output string
+	pod, err := c.k8sClient.CoreV1().Pods(c.opt.Namespace).Get(c.opt.PodName, metav1.GetOptions{})
+	if err == nil {
+		if hook.Exec != nil {
+			output, err = c.execHook(pod, hook, timeout)
+		} else if hook.HTTPGet != nil {
+			err = httpGetHook(pod, hook.HTTPGet, timeout)
+		}
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("Failed to run %s hook in pod %s, reason: %s", name, c.opt.PodName, err)
+		if output = strings.TrimSpace(output); output != "" {
+			if len(output) > maxHookOutput {
+				output = output[len(output)-maxHookOutput:]
+			}
+			msg += ", output: " + output
+		}
+		eventer.CreateEventWithLog(
+			c.k8sClient,
+			BackupEventComponent,
+			resource.ObjectReference(),
+			core.EventTypeWarning,
+			eventer.EventReasonFailedToRunHook,
+			msg,
+		)
+		if hook.OnFailure == api.HookFailurePolicyIgnore && !util.IsExecTimeout(err) {
+			return nil
+		}
+		return errors.New(msg)
+	}
+
+	eventer.CreateEventWithLog(
+		c.k8sClient,
+		BackupEventComponent,
+		resource.ObjectReference(),
+		core.EventTypeNormal,
+		eventer.EventReasonSuccessfulHook,
+		fmt.Sprintf("Executed %s hook in pod %s", name, c.opt.PodName),
+	)
+	return nil
+}
+
+func (c *Controller) execHook(pod *core.Pod, hook *api.BackupHook, timeout time.Duration) (string, error) {
+	container := hook.Container
+	if container == "" {
+		for _, c := range pod.Spec.Containers {
+			if c.Name != util.StashContainer {
+				container = c.Name
+				break
+			}
+		}
+	}
+	if container == "" {
+		return "", fmt.Errorf("no container found in pod %s to run hook", pod.Name)
+	}
+	return util.ExecIntoPod(c.clientConfig, c.k8sClient, pod.Namespace, pod.Name, container, hook.Exec.Command, timeout)
+}
+
+func httpGetHook(pod *core.Pod, action *core.HTTPGetAction, timeout time.Duration) error {
+	scheme := strings.ToLower(string(action.Scheme))
+	if scheme == "" {
+		scheme = "http"
+	}
+	host := action.Host
+	if host == "" {
+		host = "localhost" // sidecar shares network namespace with the workload pod
+	}
+	port, err := resolvePort(pod, action.Port)
+	if err != nil {
+		return err
+	}
+	path := action.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	u, err := url.Parse(fmt.Sprintf("%s://%s%s", scheme, net.JoinHostPort(host, strconv.Itoa(port)), path))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	for _, h := range action.HTTPHeaders {
+		req.Header.Add(h.Name, h.Value)
+	}
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("GET %s returned %s", u, resp.Status)
+	}
+	return nil
+}
+
+func resolvePort(pod *core.Pod, port intstr.IntOrString) (int, error) {
+	if port.Type == intstr.Int {
+		return port.IntValue(), nil
+	}
+	for _, c := range pod.Spec.Containers {
+		for _, p := range c.Ports {
+			if p.Name == port.StrVal {
+				return int(p.ContainerPort), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("port %s not found in pod %s", port.StrVal, pod.Name)
+}
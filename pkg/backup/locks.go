@@ -0,0 +1,87 @@
+package backup
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/appscode/go/log"
+	"github.com/appscode/stash/pkg/cli"
+	"github.com/appscode/stash/pkg/eventer"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// staleLockTimeout is the age after which a lock is considered stale. Restic refreshes the
+// locks of running processes every 5 minutes.
+const staleLockTimeout = 10 * time.Minute
+
+// staleLockHandler finds out whether the holder of a lock is still running from the status
+// of the pod named after the hostname of the lock.
+type staleLockHandler struct {
+	c *Controller
+}
+
+var _ cli.StaleLockHandler = &staleLockHandler{}
+
+func (h *staleLockHandler) IsStale(lock cli.Lock) (bool, error) {
+	if time.Since(lock.Time) > staleLockTimeout {
+		return true, nil
+	}
+	if lock.Hostname == h.c.opt.PodName {
+		// fileGroups may be backed up in parallel, so the lock is only stale if it was left by
+		// a previous run of this container
+		return !isResticProcess(lock.PID), nil
+	}
+
+	pod, err := h.c.k8sClient.CoreV1().Pods(h.c.opt.Namespace).Get(lock.Hostname, metav1.GetOptions{})
+	if kerr.IsNotFound(err) {
+		// hostname is not a pod name if the holder uses host network, wait for staleLockTimeout
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if pod.Status.Phase == core.PodSucceeded || pod.Status.Phase == core.PodFailed {
+		return true, nil
+	}
+	// lock is held by a running container, unless all containers were (re)started after it was taken
+	statuses := append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.State.Running != nil && status.State.Running.StartedAt.Time.Before(lock.Time) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (h *staleLockHandler) Removed(locks []cli.Lock) {
+	resource, err := h.c.stashClient.Restics(h.c.opt.Namespace).Get(h.c.opt.ResticName, metav1.GetOptions{})
+	if err != nil {
+		log.Errorf("Failed to get Restic %s/%s, reason: %s", h.c.opt.Namespace, h.c.opt.ResticName, err)
+		return
+	}
+	for _, lock := range locks {
+		h.c.recorder.Eventf(
+			resource.ObjectReference(),
+			core.EventTypeNormal,
+			eventer.EventReasonRemovedStaleLock,
+			"Removed stale lock of repository %s held by PID %d on %s since %s",
+			h.c.opt.SmartPrefix,
+			lock.PID,
+			lock.Hostname,
+			lock.Time.Format(time.RFC3339),
+		)
+	}
+}
+
+// isResticProcess checks whether pid is a running restic process of this container.
+func isResticProcess(pid int) bool {
+	comm, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/comm", pid))
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(comm)) == filepath.Base(cli.Exe)
+}
@@ -0,0 +1,63 @@
+package backup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/appscode/stash/pkg/cli"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestIsStale(t *testing.T) {
+	now := time.Now()
+	lockTime := now.Add(-time.Minute)
+	newPod := func(name string, phase core.PodPhase, started ...time.Time) *core.Pod {
+		pod := &core.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "demo"},
+			Status:     core.PodStatus{Phase: phase},
+		}
+		for _, start := range started {
+			pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, core.ContainerStatus{
+				State: core.ContainerState{Running: &core.ContainerStateRunning{StartedAt: metav1.NewTime(start)}},
+			})
+		}
+		return pod
+	}
+	h := &staleLockHandler{
+		c: &Controller{
+			k8sClient: fake.NewSimpleClientset(
+				newPod("running", core.PodRunning, now.Add(-time.Hour), now.Add(-time.Hour)),
+				newPod("restarted", core.PodRunning, now.Add(-time.Hour), now.Add(-time.Second)),
+				newPod("all-restarted", core.PodRunning, now.Add(-time.Second), now.Add(-time.Second)),
+				newPod("succeeded", core.PodSucceeded),
+				newPod("failed", core.PodFailed),
+			),
+			opt: Options{Namespace: "demo", PodName: "self"},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		lock     cli.Lock
+		expected bool
+	}{
+		{"expired", cli.Lock{Hostname: "running", Time: now.Add(-staleLockTimeout - time.Minute)}, true},
+		{"running container", cli.Lock{Hostname: "running", Time: lockTime}, false},
+		{"one container restarted", cli.Lock{Hostname: "restarted", Time: lockTime}, false},
+		{"all containers restarted", cli.Lock{Hostname: "all-restarted", Time: lockTime}, true},
+		{"succeeded pod", cli.Lock{Hostname: "succeeded", Time: lockTime}, true},
+		{"failed pod", cli.Lock{Hostname: "failed", Time: lockTime}, true},
+		{"unknown host", cli.Lock{Hostname: "node-1", Time: lockTime}, false},
+		{"own pod, process exited", cli.Lock{Hostname: "self", Time: lockTime, PID: -1}, true},
+	}
+	for _, c := range cases {
+		actual, err := h.IsStale(c.lock)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", c.name, err)
+		} else if actual != c.expected {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, actual)
+		}
+	}
+}
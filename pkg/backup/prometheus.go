@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	api "github.com/appscode/stash/apis/stash/v1alpha1"
+	"github.com/appscode/stash/pkg/cli"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/push"
 	"gopkg.in/ini.v1"
 )
@@ -42,3 +44,60 @@ func (c *Controller) GroupingKeys(resource *api.Restic) map[string]string {
 	}
 	return labels
 }
+
+// backupMetrics are read from the summary printed by restic backup for each fileGroup.
+type backupMetrics struct {
+	filesNew        *prometheus.GaugeVec
+	filesChanged    *prometheus.GaugeVec
+	filesUnmodified *prometheus.GaugeVec
+	dataAddedBytes  *prometheus.GaugeVec
+	processedFiles  *prometheus.GaugeVec
+	processedBytes  *prometheus.GaugeVec
+	duration        *prometheus.GaugeVec
+}
+
+func newBackupMetrics() *backupMetrics {
+	gauge := func(name, help string) *prometheus.GaugeVec {
+		return prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "restic",
+			Subsystem: "backup",
+			Name:      name,
+			Help:      help,
+		}, []string{"filegroup"})
+	}
+	return &backupMetrics{
+		filesNew:        gauge("files_new", "Number of new files backed up"),
+		filesChanged:    gauge("files_changed", "Number of changed files backed up"),
+		filesUnmodified: gauge("files_unmodified", "Number of unmodified files"),
+		dataAddedBytes:  gauge("data_added_bytes", "Bytes of data added to the repository"),
+		processedFiles:  gauge("processed_files", "Total number of files processed"),
+		processedBytes:  gauge("processed_bytes", "Total bytes of files processed"),
+		duration:        gauge("duration_seconds", "Seconds taken by restic to backup the fileGroup"),
+	}
+}
+
+func (m *backupMetrics) set(path string, summary *cli.BackupSummary) {
+	if summary == nil {
+		return
+	}
+	fg := sanitizeLabelValue(path)
+	m.filesNew.WithLabelValues(fg).Set(float64(summary.FilesNew))
+	m.filesChanged.WithLabelValues(fg).Set(float64(summary.FilesChanged))
+	m.filesUnmodified.WithLabelValues(fg).Set(float64(summary.FilesUnmodified))
+	m.dataAddedBytes.WithLabelValues(fg).Set(float64(summary.DataAdded))
+	m.processedFiles.WithLabelValues(fg).Set(float64(summary.TotalFilesProcessed))
+	m.processedBytes.WithLabelValues(fg).Set(float64(summary.TotalBytesProcessed))
+	m.duration.WithLabelValues(fg).Set(summary.TotalDuration)
+}
+
+func (m *backupMetrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.filesNew,
+		m.filesChanged,
+		m.filesUnmodified,
+		m.dataAddedBytes,
+		m.processedFiles,
+		m.processedBytes,
+		m.duration,
+	}
+}
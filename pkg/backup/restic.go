@@ -37,7 +37,7 @@ func (c *Controller) initResticWatcher() {
 	// of the Restic than the version which was responsible for triggering the update.
 	c.rIndexer, c.rInformer = cache.NewIndexerInformer(lw, &api.Restic{}, c.opt.ResyncPeriod, cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
-			if r, ok := obj.(*api.Restic); ok && r.Name == c.opt.ResticName && r.IsValid() == nil {
+			if r, ok := obj.(*api.Restic); ok && r.Name == c.opt.ResticName && r.Resolved().IsValid() == nil {
 				key, err := cache.MetaNamespaceKeyFunc(obj)
 				if err == nil {
 					c.rQueue.Add(key)
@@ -55,7 +55,8 @@ func (c *Controller) initResticWatcher() {
 				log.Errorln("Invalid Restic object")
 				return
 			}
-			if !util.ResticEqual(oldObj, newObj) && newObj.Name == c.opt.ResticName && newObj.IsValid() == nil {
+			// resolved, so that a change of the BackupTemplate updates the schedule
+			if newObj.Name == c.opt.ResticName && !util.ResticEqual(oldObj.Resolved(), newObj.Resolved()) && newObj.Resolved().IsValid() == nil {
 				key, err := cache.MetaNamespaceKeyFunc(new)
 				if err == nil {
 					c.rQueue.Add(key)
@@ -136,7 +137,7 @@ func (c *Controller) runResticScheduler(key string) error {
 
 		c.cron.Stop()
 	} else {
-		r := obj.(*api.Restic)
+		r := obj.(*api.Restic).Resolved()
 		glog.Infof("Sync/Add/Update for Restic %s\n", r.GetName())
 
 		err := c.configureScheduler(r)
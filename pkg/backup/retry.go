@@ -0,0 +1,89 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/appscode/go/log"
+	api "github.com/appscode/stash/apis/stash/v1alpha1"
+	"github.com/appscode/stash/pkg/eventer"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	core "k8s.io/api/core/v1"
+)
+
+const defaultRetryBackoff = 30 * time.Second
+
+// runWithRetry runs attempt until it succeeds or spec.retry.maxAttempts is reached, waiting
+// spec.retry.backoff before the first retry and doubling the delay after each retry. Waiting
+// for a retry is aborted when the backup is stopped.
+func (c *Controller) runWithRetry(resource *api.Restic, attempt func() error) error {
+	maxAttempts, backoff := 1, defaultRetryBackoff
+	if retry := resource.Spec.Retry; retry != nil {
+		if retry.MaxAttempts > 1 {
+			maxAttempts = int(retry.MaxAttempts)
+		}
+		if retry.Backoff != nil {
+			backoff = retry.Backoff.Duration
+		}
+	}
+
+	for i := 1; ; i++ {
+		err := attempt()
+		if err == nil || i >= maxAttempts {
+			return err
+		}
+		log.Warningf("Backup attempt %d of %d failed for Restic %s/%s, retrying in %s, reason: %s", i, maxAttempts, resource.Namespace, resource.Name, backoff, err)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-c.stopCh:
+			timer.Stop()
+			return fmt.Errorf("backup stopped before retry, reason: %s", err)
+		}
+		backoff *= 2
+	}
+}
+
+// withTimeout runs f with a context canceled after spec.timeout. If it expires, a BackupTimeout
+// event is created and the restic_session_timeout metric is set.
+func (c *Controller) withTimeout(resource *api.Restic, f func(ctx context.Context) error) error {
+	if resource.Spec.Timeout == nil || resource.Spec.Timeout.Duration == 0 {
+		return f(context.Background())
+	}
+	timeout := resource.Spec.Timeout.Duration
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := f(ctx)
+	timedOut := err != nil && ctx.Err() == context.DeadlineExceeded
+	if timedOut {
+		err = fmt.Errorf("backup timed out after %s, reason: %s", timeout, err)
+		eventer.CreateEventWithLog(
+			c.k8sClient,
+			BackupEventComponent,
+			resource.ObjectReference(),
+			core.EventTypeWarning,
+			eventer.EventReasonBackupTimeout,
+			fmt.Sprintf("Backup of Restic %s/%s timed out after %s", resource.Namespace, resource.Name, timeout),
+		)
+	}
+
+	if c.opt.PushgatewayURL != "" {
+		restic_session_timeout := prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "restic",
+			Subsystem: "session",
+			Name:      "timeout",
+			Help:      "Indicates if session was canceled because it exceeded the timeout",
+		})
+		if timedOut {
+			restic_session_timeout.Set(1)
+		}
+		// added to the metrics pushed by the session, which replace all metrics of the group
+		if e := push.AddCollectors(c.JobName(resource), c.GroupingKeys(resource), c.opt.PushgatewayURL, restic_session_timeout); e != nil {
+			log.Errorf("Failed to push timeout metric, reason: %s", e)
+		}
+	}
+	return err
+}
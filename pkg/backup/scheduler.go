@@ -1,8 +1,10 @@
 package backup
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"time"
 
 	"github.com/appscode/go/log"
@@ -26,7 +28,6 @@ const (
 
 func (c *Controller) BackupScheduler() error {
 	stopBackup := make(chan struct{})
-	defer close(stopBackup)
 
 	// split code from here for leader election
 	switch c.opt.Workload.Kind {
@@ -47,6 +48,7 @@ func (c *Controller) setupAndRunScheduler(stopBackup chan struct{}) error {
 		return fmt.Errorf("failed to setup backup: %s", err)
 	}
 	c.initResticWatcher() // setup restic watcher, not required for offline backup
+	c.initBackupSessionWatcher()
 	go c.runScheduler(1, stopBackup)
 	return nil
 }
@@ -73,7 +75,8 @@ func (c *Controller) electLeader(stopBackup chan struct{}) error {
 				},
 				OnStoppedLeading: func() {
 					log.Infoln("Lost leadership, stopping backup backup")
-					stopBackup <- struct{}{}
+					// closed, so that the scheduler, the watchers and retries of a running backup are all stopped
+					close(stopBackup)
 				},
 			},
 		})
@@ -82,6 +85,7 @@ func (c *Controller) electLeader(stopBackup chan struct{}) error {
 }
 
 func (c *Controller) runScheduler(threadiness int, stopCh chan struct{}) {
+	c.stopCh = stopCh
 	c.cron.Start()
 	c.locked <- struct{}{}
 
@@ -89,18 +93,21 @@ func (c *Controller) runScheduler(threadiness int, stopCh chan struct{}) {
 
 	// Let the workers stop when we are done
 	defer c.rQueue.ShutDown()
+	defer c.bsQueue.ShutDown()
 	glog.Info("Starting Stash backup")
 
 	go c.rInformer.Run(stopCh)
+	go c.bsInformer.Run(stopCh)
 
 	// Wait for all involved caches to be synced, before processing items from the queue is started
-	if !cache.WaitForCacheSync(stopCh, c.rInformer.HasSynced) {
+	if !cache.WaitForCacheSync(stopCh, c.rInformer.HasSynced, c.bsInformer.HasSynced) {
 		runtime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
 		return
 	}
 
 	for i := 0; i < threadiness; i++ {
 		go wait.Until(c.runResticWatcher, time.Second, stopCh)
+		go wait.Until(c.runBackupSessionWatcher, time.Second, stopCh)
 	}
 
 	<-stopCh
@@ -112,19 +119,32 @@ func (c *Controller) configureScheduler(r *api.Restic) error {
 	for _, v := range c.cron.Entries() {
 		c.cron.Remove(v.ID)
 	}
+	var offset time.Duration
+	if r.Spec.ScheduleJitter != nil {
+		offset = scheduleOffset(c.opt.SnapshotHostname, r.Spec.ScheduleJitter.Duration)
+		log.Infof("Scheduled backups of host %s are delayed by %s", c.opt.SnapshotHostname, offset)
+	}
 	_, err := c.cron.AddFunc(r.Spec.Schedule, func() {
+		time.Sleep(offset)
 		if err := c.runOnceForScheduler(); err != nil {
 			c.recorder.Event(r.ObjectReference(), core.EventTypeWarning, eventer.EventReasonFailedCronJob, err.Error())
 			log.Errorln(err)
 		}
 	})
-	if err != nil {
-		return err
-	}
-	_, err = c.cron.AddFunc("0 0 */3 * *", func() { c.checkOnceForScheduler() })
 	return err
 }
 
+// scheduleOffset returns the delay of scheduled backups of hostname. It is fixed for a host,
+// so that the interval between its backups is not changed, and spreads hosts evenly over jitter.
+func scheduleOffset(hostname string, jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write([]byte(hostname))
+	return time.Duration(h.Sum64() % uint64(jitter))
+}
+
 func (c *Controller) runOnceForScheduler() error {
 	select {
 	case <-c.locked:
@@ -144,50 +164,56 @@ func (c *Controller) runOnceForScheduler() error {
 	} else if err != nil {
 		return err
 	}
+	_, err = c.backupOnce(resource.Resolved())
+	return err
+}
+
+// backupOnce takes a backup using the current Restic, retrying it according to spec.retry.
+// Caller must hold c.locked.
+func (c *Controller) backupOnce(resource *api.Restic) (fgStats []api.FileGroupBackupStatus, err error) {
 	if resource.Spec.Backend.StorageSecretName == "" {
-		return errors.New("missing repository secret name")
+		return nil, errors.New("missing repository secret name")
+	}
+	err = c.runWithRetry(resource, func() error {
+		return c.withTimeout(resource, func(ctx context.Context) error {
+			return c.withBackupSlot(ctx, func() (err error) {
+				fgStats, err = c.backupAttempt(ctx, resource)
+				return
+			})
+		})
+	})
+	return
+}
+
+// withBackupSlot runs f while holding a backup slot of the namespace, if the number of concurrent
+// backups is limited. Waiting for the slot is bound by ctx, so it counts against spec.timeout.
+func (c *Controller) withBackupSlot(ctx context.Context, f func() error) error {
+	if c.semaphore == nil {
+		return f()
+	}
+	release, err := c.semaphore.acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire backup slot, reason: %s", err)
 	}
+	defer release()
+	return f()
+}
+
+func (c *Controller) backupAttempt(ctx context.Context, resource *api.Restic) ([]api.FileGroupBackupStatus, error) {
 	secret, err := c.k8sClient.CoreV1().Secrets(resource.Namespace).Get(resource.Spec.Backend.StorageSecretName, metav1.GetOptions{})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// setup restic again, previously done in setup()
 	if err = c.resticCLI.SetupEnv(resource.Spec.Backend, secret, c.opt.SmartPrefix); err != nil {
-		return err
+		return nil, err
 	}
-	if err = c.resticCLI.InitRepositoryIfAbsent(); err != nil {
-		return err
+	c.resticCLI.SetBandwidthLimit(resource.Spec.Bandwidth, &util.DefaultBandwidthLimit)
+	if err = c.resticCLI.WithContext(ctx).InitRepositoryIfAbsent(); err != nil {
+		return nil, err
 	}
 
 	// run final restic backup command
-	return c.runResticBackup(resource)
-}
-
-func (c *Controller) checkOnceForScheduler() (err error) {
-	select {
-	case <-c.locked:
-		log.Infof("Acquired lock for Restic %s/%s", c.opt.Namespace, c.opt.ResticName)
-		defer func() {
-			c.locked <- struct{}{}
-		}()
-	default:
-		log.Warningf("Skipping checkup schedule for Restic %s/%s", c.opt.Namespace, c.opt.ResticName)
-		return
-	}
-
-	var resource *api.Restic
-	resource, err = c.rLister.Restics(c.opt.Namespace).Get(c.opt.ResticName)
-	if kerr.IsNotFound(err) {
-		err = nil
-		return
-	} else if err != nil {
-		return
-	}
-
-	err = c.resticCLI.Check()
-	if err != nil {
-		c.recorder.Eventf(resource.ObjectReference(), core.EventTypeWarning, eventer.EventReasonFailedToCheck, "Repository check failed for workload %s %s/%s. Reason: %v", c.opt.Workload.Kind, c.opt.Namespace, c.opt.Workload.Name, err)
-	}
-	return
+	return c.runResticBackup(ctx, resource)
 }
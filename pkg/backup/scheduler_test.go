@@ -0,0 +1,51 @@
+package backup
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestScheduleOffset(t *testing.T) {
+	cases := []struct {
+		name   string
+		jitter time.Duration
+	}{
+		{"negative", -time.Minute},
+		{"zero", 0},
+		{"nanosecond", time.Nanosecond},
+		{"second", time.Second},
+		{"hour", time.Hour},
+		{"day", 24 * time.Hour},
+	}
+	for _, c := range cases {
+		buckets := make([]int, 4)
+		for i := 0; i < 100; i++ {
+			hostname := fmt.Sprintf("host-%d", i)
+			offset := scheduleOffset(hostname, c.jitter)
+			if c.jitter <= 0 {
+				if offset != 0 {
+					t.Errorf("%s: expected no offset for %s, got %s", c.name, hostname, offset)
+				}
+				continue
+			}
+			if offset < 0 || offset >= c.jitter {
+				t.Errorf("%s: expected offset of %s in [0, %s), got %s", c.name, hostname, c.jitter, offset)
+				continue
+			}
+			if again := scheduleOffset(hostname, c.jitter); again != offset {
+				t.Errorf("%s: expected fixed offset %s for %s, got %s", c.name, offset, hostname, again)
+			}
+			buckets[int(offset*4/c.jitter)]++
+		}
+		if c.jitter < time.Second {
+			continue
+		}
+		// hosts are spread over the whole jitter
+		for i, n := range buckets {
+			if n == 0 {
+				t.Errorf("%s: expected hosts delayed by [%s, %s), got none", c.name, c.jitter*time.Duration(i)/4, c.jitter*time.Duration(i+1)/4)
+			}
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/appscode/go/log"
+	"github.com/appscode/stash/pkg/util"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	BackupSemaphoreName = "stash-backup-semaphore"
+	semaphoreAnnotation = "stash.appscode.com/backup-semaphore"
+
+	// semaphoreLeaseDuration is the time after which the slot of a holder that stopped renewing
+	// its lease is released, eg. because its pod was killed.
+	semaphoreLeaseDuration = time.Minute
+	semaphoreRenewPeriod   = semaphoreLeaseDuration / 3
+	semaphoreRetryPeriod   = 10 * time.Second
+	// semaphoreWaitTimeout limits the time waiting for a slot if spec.timeout is not set.
+	semaphoreWaitTimeout = time.Hour
+)
+
+// semaphoreRecord is stored in an annotation of the semaphore ConfigMap, like the leader
+// election record of a ConfigMap lock.
+type semaphoreRecord struct {
+	Holders map[string]semaphoreLease `json:"holders"`
+}
+
+type semaphoreLease struct {
+	AcquireTime          metav1.Time `json:"acquireTime"`
+	RenewTime            metav1.Time `json:"renewTime"`
+	LeaseDurationSeconds int         `json:"leaseDurationSeconds"`
+}
+
+func (l semaphoreLease) expired(now time.Time) bool {
+	return l.RenewTime.Add(time.Duration(l.LeaseDurationSeconds) * time.Second).Before(now)
+}
+
+// semaphore limits the number of sidecars taking backup at the same time in a namespace.
+// Holders are recorded in a ConfigMap, which is updated using optimistic concurrency.
+//
+// The coordination API (Lease objects) is not available in the vendored client-go, and the
+// sidecars are only granted access to objects of their own namespace. So the semaphore uses a
+// ConfigMap of the namespace, the same way leader election of the sidecars does, and the limit
+// can not be enforced across namespaces.
+type semaphore struct {
+	client    kubernetes.Interface
+	namespace string
+	identity  string
+	limit     int
+}
+
+// acquire waits until a slot is available and returns a function releasing it. The lease of
+// the slot is renewed until it is released. Waiting fails when ctx is done, or after
+// semaphoreWaitTimeout if ctx has no deadline.
+func (s *semaphore) acquire(ctx context.Context) (func(), error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, semaphoreWaitTimeout)
+		defer cancel()
+	}
+	for i := 0; ; i++ {
+		ok, err := s.tryAcquireOrRenew()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			break
+		}
+		if i == 0 {
+			log.Infof("Waiting for one of %d backup slots of namespace %s", s.limit, s.namespace)
+		}
+		select {
+		case <-time.After(wait.Jitter(semaphoreRetryPeriod, 1.0)):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("no backup slot of namespace %s available, reason: %s", s.namespace, ctx.Err())
+		}
+	}
+	log.Infof("Acquired backup slot of namespace %s", s.namespace)
+
+	stopCh := make(chan struct{})
+	go wait.Until(func() {
+		if _, err := s.tryAcquireOrRenew(); err != nil {
+			log.Errorf("Failed to renew backup slot, reason: %s", err)
+		}
+	}, semaphoreRenewPeriod, stopCh)
+
+	return func() {
+		close(stopCh)
+		if err := s.release(); err != nil {
+			log.Errorf("Failed to release backup slot, reason: %s", err)
+		}
+	}, nil
+}
+
+// tryAcquireOrRenew takes a slot, or renews the lease if the slot is already held. It returns
+// false if all slots are taken by others, or the ConfigMap was updated concurrently.
+func (s *semaphore) tryAcquireOrRenew() (bool, error) {
+	cm, record, err := s.get()
+	if err != nil {
+		return false, err
+	}
+	now := metav1.Now()
+	for holder, lease := range record.Holders {
+		if lease.expired(now.Time) {
+			delete(record.Holders, holder)
+		}
+	}
+	lease, held := record.Holders[s.identity]
+	if !held {
+		if len(record.Holders) >= s.limit {
+			return false, nil
+		}
+		lease.AcquireTime = now
+	}
+	lease.RenewTime = now
+	lease.LeaseDurationSeconds = int(semaphoreLeaseDuration / time.Second)
+	record.Holders[s.identity] = lease
+	return s.update(cm, record)
+}
+
+func (s *semaphore) release() error {
+	return wait.PollImmediate(time.Second, semaphoreRenewPeriod, func() (bool, error) {
+		cm, record, err := s.get()
+		if err != nil {
+			return false, err
+		}
+		if _, held := record.Holders[s.identity]; !held {
+			return true, nil
+		}
+		delete(record.Holders, s.identity)
+		return s.update(cm, record)
+	})
+}
+
+// get returns the semaphore ConfigMap, creating it if not found.
+func (s *semaphore) get() (*core.ConfigMap, *semaphoreRecord, error) {
+	record := &semaphoreRecord{}
+	cm, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(BackupSemaphoreName, metav1.GetOptions{})
+	if kerr.IsNotFound(err) {
+		cm, err = s.client.CoreV1().ConfigMaps(s.namespace).Create(&core.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      BackupSemaphoreName,
+				Namespace: s.namespace,
+				Labels: map[string]string{
+					"app": util.AppLabelStash,
+				},
+			},
+		})
+		if kerr.IsAlreadyExists(err) {
+			cm, err = s.client.CoreV1().ConfigMaps(s.namespace).Get(BackupSemaphoreName, metav1.GetOptions{})
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if data := cm.Annotations[semaphoreAnnotation]; data != "" {
+		if err = json.Unmarshal([]byte(data), record); err != nil {
+			return nil, nil, fmt.Errorf("invalid annotation %s of ConfigMap %s/%s, reason: %s", semaphoreAnnotation, s.namespace, BackupSemaphoreName, err)
+		}
+	}
+	if record.Holders == nil {
+		record.Holders = map[string]semaphoreLease{}
+	}
+	return cm, record, nil
+}
+
+// update writes record into cm. It returns false if cm was modified since it was read.
+func (s *semaphore) update(cm *core.ConfigMap, record *semaphoreRecord) (bool, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return false, err
+	}
+	if cm.Annotations == nil {
+		cm.Annotations = map[string]string{}
+	}
+	cm.Annotations[semaphoreAnnotation] = string(data)
+	_, err = s.client.CoreV1().ConfigMaps(s.namespace).Update(cm)
+	if kerr.IsConflict(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
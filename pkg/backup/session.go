@@ -0,0 +1,195 @@
+package backup
+
+import (
+	"github.com/appscode/go/log"
+	api "github.com/appscode/stash/apis/stash/v1alpha1"
+	stash_util "github.com/appscode/stash/client/typed/stash/v1alpha1/util"
+	"github.com/appscode/stash/pkg/eventer"
+	"github.com/golang/glog"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rt "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func (c *Controller) initBackupSessionWatcher() {
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (rt.Object, error) {
+			return c.stashClient.BackupSessions(c.opt.Namespace).List(options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return c.stashClient.BackupSessions(c.opt.Namespace).Watch(options)
+		},
+	}
+
+	// create the workqueue
+	c.bsQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "backupsession")
+
+	// Only new sessions are queued. Status updates made by this or other sidecars are ignored.
+	c.bsIndexer, c.bsInformer = cache.NewIndexerInformer(lw, &api.BackupSession{}, c.opt.ResyncPeriod, cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if s, ok := obj.(*api.BackupSession); ok && c.isPendingBackupSession(s) {
+				key, err := cache.MetaNamespaceKeyFunc(obj)
+				if err == nil {
+					c.bsQueue.Add(key)
+				}
+			}
+		},
+	}, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+}
+
+// isPendingBackupSession checks whether this sidecar has yet to take the backup requested by session.
+// Sessions created before the sidecar started are ignored, so that new pods of a StatefulSet or
+// DaemonSet do not run old sessions, unless this host is expected by the session and has not
+// finished it. A Running entry of this host left behind by a previous run of the sidecar, eg.
+// if it was killed during the backup, is also pending.
+func (c *Controller) isPendingBackupSession(s *api.BackupSession) bool {
+	if s.Spec.Restic != c.opt.ResticName {
+		return false
+	}
+	for _, host := range s.Status.Hosts {
+		if host.Hostname == c.opt.SnapshotHostname {
+			return host.Phase == api.BackupSessionRunning && host.StartTime != nil && host.StartTime.Time.Before(c.startTime)
+		}
+	}
+	for _, hostname := range s.Status.ExpectedHosts {
+		if hostname == c.opt.SnapshotHostname {
+			return true
+		}
+	}
+	return !s.CreationTimestamp.Time.Before(c.startTime)
+}
+
+func (c *Controller) runBackupSessionWatcher() {
+	for c.processNextBackupSession() {
+	}
+}
+
+func (c *Controller) processNextBackupSession() bool {
+	key, quit := c.bsQueue.Get()
+	if quit {
+		return false
+	}
+	defer c.bsQueue.Done(key)
+
+	err := c.runBackupSession(key.(string))
+	if err == nil {
+		c.bsQueue.Forget(key)
+		return true
+	}
+	log.Errorf("Failed to process BackupSession %v. Reason: %s", key, err)
+
+	if c.bsQueue.NumRequeues(key) < c.opt.MaxNumRequeues {
+		glog.Infof("Error syncing BackupSession %v: %v", key, err)
+		c.bsQueue.AddRateLimited(key)
+		return true
+	}
+
+	c.bsQueue.Forget(key)
+	delete(c.bsResults, key.(string))
+	runtime.HandleError(err)
+	glog.Infof("Dropping BackupSession %q out of the queue: %v", key, err)
+	return true
+}
+
+// runBackupSession takes the backup requested by a BackupSession and records the result of this
+// host in its status. Failed backups are not retried, only failed status updates are.
+func (c *Controller) runBackupSession(key string) error {
+	obj, exists, err := c.bsIndexer.GetByKey(key)
+	if err != nil {
+		glog.Errorf("Fetching object with key %s from store failed with %v", key, err)
+		return err
+	}
+	if !exists {
+		glog.Warningf("BackupSession %s does not exist anymore\n", key)
+		delete(c.bsResults, key)
+		return nil
+	}
+	session := obj.(*api.BackupSession)
+	// The backup is done, but the session still shows this host as Running. Only retry the update.
+	if host, ok := c.bsResults[key]; ok {
+		if err = c.updateBackupSession(session, host); err != nil {
+			return err
+		}
+		delete(c.bsResults, key)
+		return nil
+	}
+	if !c.isPendingBackupSession(session) {
+		return nil
+	}
+
+	// wait for the scheduled backup, if one is running
+	<-c.locked
+	log.Infof("Acquired lock for Restic %s/%s to run BackupSession %s", c.opt.Namespace, c.opt.ResticName, session.Name)
+	defer func() {
+		c.locked <- struct{}{}
+	}()
+
+	startTime := metav1.Now()
+	host := api.HostBackupStatus{
+		Hostname:  c.opt.SnapshotHostname,
+		Phase:     api.BackupSessionRunning,
+		StartTime: &startTime,
+	}
+	if err = c.updateBackupSession(session, host); err != nil {
+		return err
+	}
+
+	var resource *api.Restic
+	resource, err = c.rLister.Restics(c.opt.Namespace).Get(c.opt.ResticName)
+	if err == nil {
+		host.FileGroups, err = c.backupOnce(resource.Resolved())
+	}
+	endTime := metav1.Now()
+	host.EndTime = &endTime
+	if err != nil {
+		host.Phase = api.BackupSessionFailed
+		host.Error = err.Error()
+		c.recorder.Eventf(
+			session.ObjectReference(),
+			core.EventTypeWarning,
+			eventer.EventReasonFailedToBackup,
+			"Failed to backup host %s, reason: %s",
+			c.opt.SnapshotHostname,
+			err,
+		)
+	} else {
+		host.Phase = api.BackupSessionSucceeded
+		c.recorder.Eventf(
+			session.ObjectReference(),
+			core.EventTypeNormal,
+			eventer.EventReasonSuccessfulBackup,
+			"Backed up host %s",
+			c.opt.SnapshotHostname,
+		)
+	}
+	if err = c.updateBackupSession(session, host); err != nil {
+		c.bsResults[key] = host
+		return err
+	}
+	return nil
+}
+
+// updateBackupSession sets status of this host in session. Sidecars of all hosts update
+// the same session, so update is retried on conflict.
+func (c *Controller) updateBackupSession(session *api.BackupSession, host api.HostBackupStatus) error {
+	_, err := stash_util.TryUpdateBackupSession(c.stashClient, session.ObjectMeta, func(in *api.BackupSession) *api.BackupSession {
+		found := false
+		for i := range in.Status.Hosts {
+			if in.Status.Hosts[i].Hostname == host.Hostname {
+				in.Status.Hosts[i] = host
+				found = true
+				break
+			}
+		}
+		if !found {
+			in.Status.Hosts = append(in.Status.Hosts, host)
+		}
+		in.Status.Phase = in.Status.CalculatePhase()
+		return in
+	})
+	return err
+}
@@ -6,6 +6,7 @@ import (
 	cs "github.com/appscode/stash/client/typed/stash/v1alpha1"
 	"github.com/appscode/stash/pkg/cli"
 	"github.com/appscode/stash/pkg/eventer"
+	"github.com/appscode/stash/pkg/util"
 	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -44,6 +45,7 @@ func (c *Controller) Run() (err error) {
 	if err != nil {
 		return
 	}
+	restic = restic.Resolved()
 
 	defer func() {
 		if err != nil {
@@ -77,7 +79,8 @@ func (c *Controller) Run() (err error) {
 	if err = cli.SetupEnv(restic.Spec.Backend, secret, c.opt.SmartPrefix); err != nil {
 		return
 	}
+	cli.SetBandwidthLimit(restic.Spec.Bandwidth, &util.DefaultBandwidthLimit)
 
-	err = cli.Check()
+	err = cli.Check("")
 	return
 }
@@ -1,11 +1,16 @@
 package cli
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/appscode/go/log"
@@ -17,9 +22,12 @@ const (
 	RESTIC_REPOSITORY = "RESTIC_REPOSITORY"
 	RESTIC_PASSWORD   = "RESTIC_PASSWORD"
 	TMPDIR            = "TMPDIR"
+	CA_CERT_DATA      = "CA_CERT_DATA"
+	INSECURE_TLS      = "INSECURE_TLS"
 
 	AWS_ACCESS_KEY_ID     = "AWS_ACCESS_KEY_ID"
 	AWS_SECRET_ACCESS_KEY = "AWS_SECRET_ACCESS_KEY"
+	AWS_DEFAULT_REGION    = "AWS_DEFAULT_REGION"
 
 	GOOGLE_PROJECT_ID               = "GOOGLE_PROJECT_ID"
 	GOOGLE_SERVICE_ACCOUNT_JSON_KEY = "GOOGLE_SERVICE_ACCOUNT_JSON_KEY"
@@ -31,6 +39,9 @@ const (
 	REST_SERVER_USERNAME = "REST_SERVER_USERNAME"
 	REST_SERVER_PASSWORD = "REST_SERVER_PASSWORD"
 
+	SSH_PRIVATE_KEY = "SSH_PRIVATE_KEY"
+	SSH_KNOWN_HOSTS = "SSH_KNOWN_HOSTS"
+
 	B2_ACCOUNT_ID  = "B2_ACCOUNT_ID"
 	B2_ACCOUNT_KEY = "B2_ACCOUNT_KEY"
 
@@ -54,109 +65,200 @@ const (
 	OS_AUTH_TOKEN  = "OS_AUTH_TOKEN"
 )
 
+// RequiredSecretKeys returns the keys that the storage secret of backend must contain.
+func RequiredSecretKeys(backend api.Backend) []string {
+	keys := []string{RESTIC_PASSWORD}
+	switch {
+	case backend.S3 != nil:
+		keys = append(keys, AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY)
+	case backend.GCS != nil:
+		keys = append(keys, GOOGLE_PROJECT_ID, GOOGLE_SERVICE_ACCOUNT_JSON_KEY)
+	case backend.Azure != nil:
+		keys = append(keys, AZURE_ACCOUNT_NAME, AZURE_ACCOUNT_KEY)
+	case backend.B2 != nil:
+		keys = append(keys, B2_ACCOUNT_ID, B2_ACCOUNT_KEY)
+	case backend.SFTP != nil:
+		keys = append(keys, SSH_PRIVATE_KEY, SSH_KNOWN_HOSTS)
+	}
+	// Swift supports several authentication schemes, each using a different set of keys.
+	return keys
+}
+
 func (w *ResticWrapper) SetupEnv(backend api.Backend, secret *core.Secret, autoPrefix string) error {
+	// SetupEnv is called before every backup, so values of a previous backend or secret are cleared
+	w.env = map[string]string{}
+	w.cacertFile = ""
+	w.insecureTLS = false
+	w.extendedOptions = nil
+
 	if v, ok := secret.Data[RESTIC_PASSWORD]; !ok {
 		return errors.New("missing repository password")
 	} else {
-		w.sh.SetEnv(RESTIC_PASSWORD, string(v))
+		w.setEnv(RESTIC_PASSWORD, string(v))
 	}
 
 	tmpDir := filepath.Join(w.scratchDir, "restic-tmp")
 	if err := os.MkdirAll(tmpDir, 0755); err != nil {
 		return err
 	}
-	w.sh.SetEnv(TMPDIR, tmpDir)
+	w.setEnv(TMPDIR, tmpDir)
+
+	if v, ok := secret.Data[CA_CERT_DATA]; ok {
+		certDir := filepath.Join(w.scratchDir, "cacerts")
+		if err := os.MkdirAll(certDir, 0755); err != nil {
+			return err
+		}
+		w.cacertFile = filepath.Join(certDir, "ca.crt")
+		if err := ioutil.WriteFile(w.cacertFile, v, 0644); err != nil {
+			return err
+		}
+	}
+	if v, ok := secret.Data[INSECURE_TLS]; ok {
+		insecure, err := strconv.ParseBool(string(v))
+		if err != nil {
+			return fmt.Errorf("invalid value %s for %s", string(v), INSECURE_TLS)
+		}
+		w.insecureTLS = insecure
+	}
 
 	if backend.Local != nil {
 		r := filepath.Join(backend.Local.MountPath, autoPrefix)
 		if err := os.MkdirAll(r, 0755); err != nil {
 			return err
 		}
-		w.sh.SetEnv(RESTIC_REPOSITORY, r)
+		w.setEnv(RESTIC_REPOSITORY, r)
 	} else if backend.S3 != nil {
 		prefix := strings.TrimPrefix(filepath.Join(backend.S3.Bucket, backend.S3.Prefix, autoPrefix), "/")
 		r := fmt.Sprintf("s3:%s/%s", backend.S3.Endpoint, prefix)
-		w.sh.SetEnv(RESTIC_REPOSITORY, r)
-		w.sh.SetEnv(AWS_ACCESS_KEY_ID, string(secret.Data[AWS_ACCESS_KEY_ID]))
-		w.sh.SetEnv(AWS_SECRET_ACCESS_KEY, string(secret.Data[AWS_SECRET_ACCESS_KEY]))
+		w.setEnv(RESTIC_REPOSITORY, r)
+		w.setEnv(AWS_ACCESS_KEY_ID, string(secret.Data[AWS_ACCESS_KEY_ID]))
+		w.setEnv(AWS_SECRET_ACCESS_KEY, string(secret.Data[AWS_SECRET_ACCESS_KEY]))
+		if backend.S3.Region != "" {
+			w.setEnv(AWS_DEFAULT_REGION, backend.S3.Region)
+			w.extendedOptions = append(w.extendedOptions, "s3.region="+backend.S3.Region)
+		}
+		if backend.S3.ForcePathStyle {
+			w.extendedOptions = append(w.extendedOptions, "s3.bucket-lookup=path")
+		}
+		if backend.S3.StorageClass != "" {
+			w.extendedOptions = append(w.extendedOptions, "s3.storage-class="+backend.S3.StorageClass)
+		}
 	} else if backend.GCS != nil {
 		prefix := strings.TrimPrefix(filepath.Join(backend.GCS.Prefix, autoPrefix), "/")
 		r := fmt.Sprintf("gs:%s:/%s", backend.GCS.Bucket, prefix)
-		w.sh.SetEnv(RESTIC_REPOSITORY, r)
-		w.sh.SetEnv(GOOGLE_PROJECT_ID, string(secret.Data[GOOGLE_PROJECT_ID]))
+		w.setEnv(RESTIC_REPOSITORY, r)
+		w.setEnv(GOOGLE_PROJECT_ID, string(secret.Data[GOOGLE_PROJECT_ID]))
 		jsonKeyPath := filepath.Join(w.scratchDir, "gcs_sa.json")
 		err := ioutil.WriteFile(jsonKeyPath, secret.Data[GOOGLE_SERVICE_ACCOUNT_JSON_KEY], 0644)
 		if err != nil {
 			return err
 		}
-		w.sh.SetEnv(GOOGLE_APPLICATION_CREDENTIALS, jsonKeyPath)
+		w.setEnv(GOOGLE_APPLICATION_CREDENTIALS, jsonKeyPath)
 	} else if backend.Azure != nil {
 		prefix := strings.TrimPrefix(filepath.Join(backend.Azure.Prefix, autoPrefix), "/")
 		r := fmt.Sprintf("azure:%s:/%s", backend.Azure.Container, prefix)
-		w.sh.SetEnv(RESTIC_REPOSITORY, r)
-		w.sh.SetEnv(AZURE_ACCOUNT_NAME, string(secret.Data[AZURE_ACCOUNT_NAME]))
-		w.sh.SetEnv(AZURE_ACCOUNT_KEY, string(secret.Data[AZURE_ACCOUNT_KEY]))
+		w.setEnv(RESTIC_REPOSITORY, r)
+		w.setEnv(AZURE_ACCOUNT_NAME, string(secret.Data[AZURE_ACCOUNT_NAME]))
+		w.setEnv(AZURE_ACCOUNT_KEY, string(secret.Data[AZURE_ACCOUNT_KEY]))
 	} else if backend.Swift != nil {
 		prefix := strings.TrimPrefix(filepath.Join(backend.Swift.Prefix, autoPrefix), "/")
 		r := fmt.Sprintf("swift:%s:/%s", backend.Swift.Container, prefix)
-		w.sh.SetEnv(RESTIC_REPOSITORY, r)
+		w.setEnv(RESTIC_REPOSITORY, r)
 		// For keystone v1 authentication
-		w.sh.SetEnv(ST_AUTH, string(secret.Data[ST_AUTH]))
-		w.sh.SetEnv(ST_USER, string(secret.Data[ST_USER]))
-		w.sh.SetEnv(ST_KEY, string(secret.Data[ST_KEY]))
+		w.setEnv(ST_AUTH, string(secret.Data[ST_AUTH]))
+		w.setEnv(ST_USER, string(secret.Data[ST_USER]))
+		w.setEnv(ST_KEY, string(secret.Data[ST_KEY]))
 		// For keystone v2 authentication (some variables are optional)
-		w.sh.SetEnv(OS_AUTH_URL, string(secret.Data[OS_AUTH_URL]))
-		w.sh.SetEnv(OS_REGION_NAME, string(secret.Data[OS_REGION_NAME]))
-		w.sh.SetEnv(OS_USERNAME, string(secret.Data[OS_USERNAME]))
-		w.sh.SetEnv(OS_PASSWORD, string(secret.Data[OS_PASSWORD]))
-		w.sh.SetEnv(OS_TENANT_ID, string(secret.Data[OS_TENANT_ID]))
-		w.sh.SetEnv(OS_TENANT_NAME, string(secret.Data[OS_TENANT_NAME]))
+		w.setEnv(OS_AUTH_URL, string(secret.Data[OS_AUTH_URL]))
+		w.setEnv(OS_REGION_NAME, string(secret.Data[OS_REGION_NAME]))
+		w.setEnv(OS_USERNAME, string(secret.Data[OS_USERNAME]))
+		w.setEnv(OS_PASSWORD, string(secret.Data[OS_PASSWORD]))
+		w.setEnv(OS_TENANT_ID, string(secret.Data[OS_TENANT_ID]))
+		w.setEnv(OS_TENANT_NAME, string(secret.Data[OS_TENANT_NAME]))
 		// For keystone v3 authentication (some variables are optional)
-		w.sh.SetEnv(OS_USER_DOMAIN_NAME, string(secret.Data[OS_USER_DOMAIN_NAME]))
-		w.sh.SetEnv(OS_PROJECT_NAME, string(secret.Data[AZURE_ACCOUNT_NAME]))
-		w.sh.SetEnv(AZURE_ACCOUNT_NAME, string(secret.Data[OS_PROJECT_NAME]))
-		w.sh.SetEnv(OS_PROJECT_DOMAIN_NAME, string(secret.Data[OS_PROJECT_DOMAIN_NAME]))
+		w.setEnv(OS_USER_DOMAIN_NAME, string(secret.Data[OS_USER_DOMAIN_NAME]))
+		w.setEnv(OS_PROJECT_NAME, string(secret.Data[AZURE_ACCOUNT_NAME]))
+		w.setEnv(AZURE_ACCOUNT_NAME, string(secret.Data[OS_PROJECT_NAME]))
+		w.setEnv(OS_PROJECT_DOMAIN_NAME, string(secret.Data[OS_PROJECT_DOMAIN_NAME]))
 		// For authentication based on tokens
-		w.sh.SetEnv(OS_STORAGE_URL, string(secret.Data[OS_STORAGE_URL]))
-		w.sh.SetEnv(OS_AUTH_TOKEN, string(secret.Data[OS_AUTH_TOKEN]))
+		w.setEnv(OS_STORAGE_URL, string(secret.Data[OS_STORAGE_URL]))
+		w.setEnv(OS_AUTH_TOKEN, string(secret.Data[OS_AUTH_TOKEN]))
 	} else if backend.B2 != nil {
 		prefix := strings.TrimPrefix(filepath.Join(backend.B2.Prefix, autoPrefix), "/")
 		r := fmt.Sprintf("b2:%s:/%s", backend.B2.Bucket, prefix)
-		w.sh.SetEnv(RESTIC_REPOSITORY, r)
-		w.sh.SetEnv(B2_ACCOUNT_ID, string(secret.Data[B2_ACCOUNT_ID]))
-		w.sh.SetEnv(B2_ACCOUNT_KEY, string(secret.Data[B2_ACCOUNT_KEY]))
-		/*
-			} else if backend.Rest != nil {
-				u, err := url.Parse(backend.Rest.URL)
-				if err != nil {
-					return err
-				}
-				if username, ok := secret.Data[REST_SERVER_USERNAME]; ok {
-					if password, ok := secret.Data[REST_SERVER_PASSWORD]; ok {
-						u.User = url.UserPassword(string(username), string(password))
-					} else {
-						u.User = url.User(string(username))
-					}
-				}
-				u.Path = filepath.Join(u.Path, autoPrefix) // TODO: check
-				r := fmt.Sprintf("rest:%s", u.String())
-				w.sh.SetEnv(RESTIC_REPOSITORY, r)
-			} else if backend.B2 != nil {
-				prefix := filepath.Join(backend.B2.Prefix, autoPrefix)
-				r := fmt.Sprintf("b2:%s:%s", backend.B2.Bucket, prefix)
-				w.sh.SetEnv(RESTIC_REPOSITORY, r)
-				w.sh.SetEnv(B2_ACCOUNT_ID, string(secret.Data[B2_ACCOUNT_ID]))
-				w.sh.SetEnv(B2_ACCOUNT_KEY, string(secret.Data[B2_ACCOUNT_KEY]))
-		*/
+		w.setEnv(RESTIC_REPOSITORY, r)
+		w.setEnv(B2_ACCOUNT_ID, string(secret.Data[B2_ACCOUNT_ID]))
+		w.setEnv(B2_ACCOUNT_KEY, string(secret.Data[B2_ACCOUNT_KEY]))
+	} else if backend.Rest != nil {
+		u, err := url.Parse(backend.Rest.URL)
+		if err != nil {
+			return err
+		}
+		if username, ok := secret.Data[REST_SERVER_USERNAME]; ok {
+			if password, ok := secret.Data[REST_SERVER_PASSWORD]; ok {
+				u.User = url.UserPassword(string(username), string(password))
+			} else {
+				u.User = url.User(string(username))
+			}
+		}
+		u.Path = path.Join(u.Path, autoPrefix) + "/"
+		r := fmt.Sprintf("rest:%s", u.String())
+		w.setEnv(RESTIC_REPOSITORY, r)
+	} else if backend.SFTP != nil {
+		sshCmd, err := w.setupSSH(backend.SFTP, secret)
+		if err != nil {
+			return err
+		}
+		r := fmt.Sprintf("sftp:%s@%s:%s", backend.SFTP.User, backend.SFTP.Host, filepath.Join(backend.SFTP.Path, autoPrefix))
+		w.setEnv(RESTIC_REPOSITORY, r)
+		w.extendedOptions = append(w.extendedOptions, "sftp.command="+sshCmd)
 	}
 	return nil
 }
 
+// setupSSH writes the ssh private key and known_hosts from secret into scratch dir
+// and returns the ssh command restic should use to connect to the sftp server.
+func (w *ResticWrapper) setupSSH(spec *api.SFTPSpec, secret *core.Secret) (string, error) {
+	privateKey, ok := secret.Data[SSH_PRIVATE_KEY]
+	if !ok {
+		return "", errors.New("missing ssh private key")
+	}
+	knownHosts, ok := secret.Data[SSH_KNOWN_HOSTS]
+	if !ok {
+		return "", errors.New("missing ssh known hosts")
+	}
+
+	sshDir := filepath.Join(w.scratchDir, "ssh")
+	if err := os.MkdirAll(sshDir, 0700); err != nil {
+		return "", err
+	}
+	keyFile := filepath.Join(sshDir, "id_key")
+	if err := ioutil.WriteFile(keyFile, privateKey, 0600); err != nil {
+		return "", err
+	}
+	knownHostsFile := filepath.Join(sshDir, "known_hosts")
+	if err := ioutil.WriteFile(knownHostsFile, knownHosts, 0600); err != nil {
+		return "", err
+	}
+
+	port := spec.Port
+	if port == 0 {
+		port = 22
+	}
+	return fmt.Sprintf("ssh %s@%s -p %d -i %s -o UserKnownHostsFile=%s -o StrictHostKeyChecking=yes -o IdentitiesOnly=yes -s sftp",
+		spec.User, spec.Host, port, keyFile, knownHostsFile), nil
+}
+
 func (w *ResticWrapper) DumpEnv() error {
-	out, err := w.sh.Command("env").Output()
-	if err != nil {
-		return err
+	keys := make([]string, 0, len(w.env))
+	for k := range w.env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var out bytes.Buffer
+	for _, k := range keys {
+		fmt.Fprintf(&out, "%s=%s\n", k, w.env[k])
 	}
-	log.Debugf("ENV:\n%s", string(out))
+	log.Debugf("ENV:\n%s", out.String())
 	return nil
 }
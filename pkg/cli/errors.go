@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"context"
+	"strings"
+)
+
+type ErrorReason string
+
+const (
+	ErrorReasonUnknown            ErrorReason = "Unknown"
+	ErrorReasonCanceled           ErrorReason = "Canceled"
+	ErrorReasonLocked             ErrorReason = "Locked"
+	ErrorReasonWrongPassword      ErrorReason = "WrongPassword"
+	ErrorReasonRepositoryNotFound ErrorReason = "RepositoryNotFound"
+)
+
+// Error is returned when a restic command fails. Reason is detected from the message
+// printed by restic, so it is ErrorReasonUnknown for messages not known to Stash.
+type Error struct {
+	Reason ErrorReason
+	// Message is the reason of the failure printed by restic to stderr.
+	Message string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return e.Err.Error()
+	}
+	return e.Err.Error() + ": " + e.Message
+}
+
+func newError(ctx context.Context, err error, message string) *Error {
+	e := &Error{
+		Reason:  ErrorReasonUnknown,
+		Message: message,
+		Err:     err,
+	}
+	switch {
+	case ctx.Err() != nil:
+		e.Reason = ErrorReasonCanceled
+		e.Err = ctx.Err()
+	case strings.Contains(message, "repository is already locked"):
+		e.Reason = ErrorReasonLocked
+	case strings.Contains(message, "wrong password or no key found"):
+		e.Reason = ErrorReasonWrongPassword
+	case strings.Contains(message, "Is there a repository at the following location?"),
+		strings.Contains(message, "unable to open config file"):
+		e.Reason = ErrorReasonRepositoryNotFound
+	}
+	return e
+}
+
+// ReasonForError returns the reason of a restic error, or ErrorReasonUnknown if err
+// was not returned by a restic command.
+func ReasonForError(err error) ErrorReason {
+	if e, ok := err.(*Error); ok {
+		return e.Reason
+	}
+	return ErrorReasonUnknown
+}
+
+func IsCanceled(err error) bool {
+	return ReasonForError(err) == ErrorReasonCanceled
+}
+
+func IsLocked(err error) bool {
+	return ReasonForError(err) == ErrorReasonLocked
+}
+
+func IsWrongPassword(err error) bool {
+	return ReasonForError(err) == ErrorReasonWrongPassword
+}
+
+func IsRepositoryNotFound(err error) bool {
+	return ReasonForError(err) == ErrorReasonRepositoryNotFound
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestNewError(t *testing.T) {
+	exitErr := errors.New("exit status 1")
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// messages printed by restic 0.12.0
+	cases := []struct {
+		name     string
+		ctx      context.Context
+		message  string
+		reason   ErrorReason
+		expected string
+	}{
+		{
+			name: "locked",
+			ctx:  context.Background(),
+			message: "unable to create lock in backend: repository is already locked by PID 35 on stash-0 by root (UID 0, GID 0)\n" +
+				"lock was created at 2021-03-03 12:00:00 (1m0s ago)\nstorage ID 1a2b3c4d\nthe `unlock` command can be used to remove stale locks",
+			reason: ErrorReasonLocked,
+		},
+		{
+			name:     "wrong password",
+			ctx:      context.Background(),
+			message:  "Fatal: wrong password or no key found",
+			reason:   ErrorReasonWrongPassword,
+			expected: "exit status 1: Fatal: wrong password or no key found",
+		},
+		{
+			name:    "local repository not found",
+			ctx:     context.Background(),
+			message: "Fatal: unable to open config file: Stat: stat /repo/config: no such file or directory\nIs there a repository at the following location?\n/repo",
+			reason:  ErrorReasonRepositoryNotFound,
+		},
+		{
+			name:    "s3 repository not found",
+			ctx:     context.Background(),
+			message: "Fatal: unable to open config file: Stat: The specified key does not exist.",
+			reason:  ErrorReasonRepositoryNotFound,
+		},
+		{
+			name:     "unknown",
+			ctx:      context.Background(),
+			message:  "Fatal: unable to save snapshot: write /repo/snapshots/1a2b: no space left on device",
+			reason:   ErrorReasonUnknown,
+			expected: "exit status 1: Fatal: unable to save snapshot: write /repo/snapshots/1a2b: no space left on device",
+		},
+		{
+			name:     "no message",
+			ctx:      context.Background(),
+			reason:   ErrorReasonUnknown,
+			expected: "exit status 1",
+		},
+		{
+			name:     "canceled",
+			ctx:      canceled,
+			message:  "repository is already locked by PID 35 on stash-0",
+			reason:   ErrorReasonCanceled,
+			expected: "context canceled: repository is already locked by PID 35 on stash-0",
+		},
+	}
+	for _, c := range cases {
+		err := newError(c.ctx, exitErr, c.message)
+		if err.Reason != c.reason {
+			t.Errorf("%s: expected reason %s, got %s", c.name, c.reason, err.Reason)
+		}
+		if ReasonForError(err) != c.reason {
+			t.Errorf("%s: expected ReasonForError %s, got %s", c.name, c.reason, ReasonForError(err))
+		}
+		if c.expected != "" && err.Error() != c.expected {
+			t.Errorf("%s: expected %q, got %q", c.name, c.expected, err.Error())
+		}
+	}
+	if reason := ReasonForError(exitErr); reason != ErrorReasonUnknown {
+		t.Errorf("expected reason %s for other errors, got %s", ErrorReasonUnknown, reason)
+	}
+}
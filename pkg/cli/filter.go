@@ -0,0 +1,227 @@
+package cli
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	api "github.com/appscode/stash/apis/stash/v1alpha1"
+)
+
+// filterArgs returns the restic flags for the excludes and filesystem options of fg. Restic
+// backup has no include flag, so includes are converted into an exclude file listing the
+// entries under fg.Path that neither match nor contain a match. The returned cleanup
+// function removes that file.
+func (w *ResticWrapper) filterArgs(fg api.FileGroup) ([]interface{}, func(), error) {
+	args := make([]interface{}, 0)
+	for _, pattern := range fg.Excludes {
+		args = append(args, "--exclude", pattern)
+	}
+	if fg.ExcludeFile != "" {
+		args = append(args, "--exclude-file", fg.ExcludeFile)
+	}
+	if fg.ExcludeCaches {
+		args = append(args, "--exclude-caches")
+	}
+	if fg.OneFileSystem {
+		args = append(args, "--one-file-system")
+	}
+	if len(fg.Includes) == 0 {
+		return args, func() {}, nil
+	}
+
+	// relative patterns are anchored to fg.Path, so that only directories along the patterns are read
+	patterns := make([]string, 0, len(fg.Includes))
+	for _, pattern := range fg.Includes {
+		patterns = append(patterns, anchorPattern(fg.Path, pattern))
+	}
+	included, excludes, err := walkIncludes(fg.Path, patterns)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !included {
+		// nothing is matched, back up fg.Path without its contents
+		excludes = []string{escapePattern(fg.Path) + "/*"}
+	}
+	file, err := ioutil.TempFile(w.scratchDir, "restic-includes-")
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() { os.Remove(file.Name()) }
+	for _, path := range excludes {
+		if _, err = file.WriteString(path + "\n"); err != nil {
+			break
+		}
+	}
+	if e2 := file.Close(); err == nil {
+		err = e2
+	}
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return append(args, "--exclude-file", file.Name()), cleanup, nil
+}
+
+// anchorPattern returns pattern relative to dir, if it is not absolute.
+func anchorPattern(dir, pattern string) string {
+	if filepath.IsAbs(pattern) {
+		return pattern
+	}
+	return filepath.Join(dir, pattern)
+}
+
+// walkIncludes returns whether path matches or contains a match of the absolute patterns, and the
+// escaped paths to be excluded, so that only the matches and their parent directories are backed up.
+// Only directories matching the leading components of a pattern are read. Other entries are excluded
+// as a whole, without reading their contents. Symlinks are not followed, like restic does.
+func walkIncludes(path string, patterns []string) (bool, []string, error) {
+	if matchAny(patterns, path) {
+		return true, nil, nil
+	}
+	if !mayContainMatch(patterns, path) {
+		return false, nil, nil
+	}
+	info, err := os.Lstat(path)
+	if err != nil {
+		return false, nil, err
+	}
+	if !info.IsDir() {
+		return false, nil, nil
+	}
+	entries, err := ioutil.ReadDir(path)
+	if err != nil {
+		return false, nil, err
+	}
+
+	included := false
+	excludes := make([]string, 0)
+	for _, entry := range entries {
+		child := filepath.Join(path, entry.Name())
+		ok, childExcludes, err := walkIncludes(child, patterns)
+		if err != nil {
+			return false, nil, err
+		}
+		if ok {
+			included = true
+			excludes = append(excludes, childExcludes...)
+		} else {
+			excludes = append(excludes, escapePattern(child))
+		}
+	}
+	return included, excludes, nil
+}
+
+func matchAny(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		if matchPattern(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// mayContainMatch returns whether path may contain a match of one of the absolute patterns,
+// ie. path matches the leading components of a pattern.
+func mayContainMatch(patterns []string, path string) bool {
+	// "/" would be split into two empty components
+	strs := strings.Split(strings.TrimSuffix(path, "/"), "/")
+	for _, pattern := range patterns {
+		if matchLeadingComponents(strings.Split(filepath.Clean(pattern), "/"), strs) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchLeadingComponents(patterns, strs []string) bool {
+	for i, s := range strs {
+		if i >= len(patterns) {
+			return false
+		}
+		if patterns[i] == "**" {
+			// matches any number of directories
+			return true
+		}
+		if ok, err := filepath.Match(patterns[i], s); err != nil || !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// matchPattern matches path like restic matches include and exclude patterns: "**" matches
+// any number of directories, and a relative pattern matches at any depth. As a pattern may
+// match a parent directory, the contents of a matched directory are matched too.
+func matchPattern(pattern, path string) bool {
+	pattern = filepath.Clean(pattern)
+	return matchComponents(strings.Split(pattern, "/"), strings.Split(path, "/"))
+}
+
+func matchComponents(patterns, strs []string) bool {
+	for pos, p := range patterns {
+		if p != "**" {
+			continue
+		}
+		for i := 0; i <= len(strs)-len(patterns)+1; i++ {
+			expanded := append([]string{}, patterns[:pos]...)
+			for k := 0; k < i; k++ {
+				expanded = append(expanded, "*")
+			}
+			expanded = append(expanded, patterns[pos+1:]...)
+			if matchComponents(expanded, strs) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(patterns) == 0 {
+		return len(strs) == 0
+	}
+	if len(patterns) > len(strs) {
+		return false
+	}
+	maxOffset := len(strs) - len(patterns)
+	if patterns[0] == "" {
+		// absolute pattern
+		maxOffset = 0
+	}
+outer:
+	for offset := maxOffset; offset >= 0; offset-- {
+		for i := range patterns {
+			if ok, err := filepath.Match(patterns[i], strs[offset+i]); err != nil || !ok {
+				continue outer
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// escapePattern returns a pattern matching only path. Restic trims spaces and expands
+// environment variables in lines of an exclude file, so "$", newlines and trailing spaces
+// are replaced by "?".
+func escapePattern(path string) string {
+	var b bytes.Buffer
+	for i, r := range path {
+		switch {
+		case r == '*' || r == '?' || r == '[' || r == '\\':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r == '$' || r == '\n' || r == '\r':
+			b.WriteRune('?')
+		case r == ' ' || r == '\t':
+			if strings.TrimSpace(path[i:]) == "" {
+				b.WriteRune('?')
+			} else {
+				b.WriteRune(r)
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,197 @@
+package cli
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMatchPattern(t *testing.T) {
+	cases := []struct {
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{"/data/config", "/data/config", true},
+		{"/data/config", "/data/config/app.yaml", true},
+		{"/data/config/", "/data/config/app.yaml", true},
+		{"/data/config", "/data/configs", false},
+		{"/data/config", "/data", false},
+		{"/data/config", "/backup/data/config", false},
+		{"*.db", "/data/x/a.db", true},
+		{"*.db", "/data/a.db.bak", false},
+		{"x/*.db", "/data/x/a.db", true},
+		{"x/*.db", "/data/y/a.db", false},
+		{"/data/*/app.yaml", "/data/x/app.yaml", true},
+		{"/data/*/app.yaml", "/data/x/y/app.yaml", false},
+		{"/data/**/*.db", "/data/a.db", true},
+		{"/data/**/*.db", "/data/x/y/a.db", true},
+		{"/data/**/*.db", "/other/a.db", false},
+		{"/data/**", "/data/x/y", true},
+		{"**", "/data", true},
+		{"/data/[ab].db", "/data/b.db", true},
+		{"/data/[ab].db", "/data/c.db", false},
+	}
+	for _, c := range cases {
+		if actual := matchPattern(c.pattern, c.path); actual != c.expected {
+			t.Errorf("%s %s: expected %v, got %v", c.pattern, c.path, c.expected, actual)
+		}
+	}
+}
+
+func TestMayContainMatch(t *testing.T) {
+	cases := []struct {
+		patterns []string
+		path     string
+		expected bool
+	}{
+		{[]string{"/data/config/app.yaml"}, "/", true},
+		{[]string{"/data/config/app.yaml"}, "/data", true},
+		{[]string{"/data/config/app.yaml"}, "/data/config", true},
+		{[]string{"/data/config/app.yaml"}, "/data/cache", false},
+		{[]string{"/data/config/app.yaml"}, "/data/config/app.yaml/x", false},
+		{[]string{"/data/*/app.yaml"}, "/data/x", true},
+		{[]string{"/data/**/*.db"}, "/data/x/y/z", true},
+		{[]string{"/data/**/*.db"}, "/other", false},
+		{[]string{"/data/cache", "/data/config/*"}, "/data/config", true},
+	}
+	for _, c := range cases {
+		if actual := mayContainMatch(c.patterns, c.path); actual != c.expected {
+			t.Errorf("%v %s: expected %v, got %v", c.patterns, c.path, c.expected, actual)
+		}
+	}
+}
+
+func TestAnchorPattern(t *testing.T) {
+	cases := []struct {
+		dir      string
+		pattern  string
+		expected string
+	}{
+		{"/data", "/data/config", "/data/config"},
+		{"/data", "/other/config", "/other/config"},
+		{"/data", "config", "/data/config"},
+		{"/data/", "./config/", "/data/config"},
+		{"/data", "**/*.db", "/data/**/*.db"},
+	}
+	for _, c := range cases {
+		if actual := anchorPattern(c.dir, c.pattern); actual != c.expected {
+			t.Errorf("%s %s: expected %q, got %q", c.dir, c.pattern, c.expected, actual)
+		}
+	}
+}
+
+func TestEscapePattern(t *testing.T) {
+	cases := []struct {
+		path     string
+		expected string
+		other    string // similar path, not matched by the escaped pattern
+	}{
+		{"/data/config", "/data/config", "/data/configs"},
+		{"/data/a*b", `/data/a\*b`, "/data/axxb"},
+		{"/data/[x]?", `/data/\[x]\?`, "/data/x1"},
+		{`/data/back\slash`, `/data/back\\slash`, "/data/backslash"},
+		{"/data/$HOME", "/data/?HOME", ""},
+		{"/data/a b", "/data/a b", "/data/a"},
+		{"/data/a b ", "/data/a b?", "/data/a b"},
+		{"/data/line\nbreak", "/data/line?break", ""},
+	}
+	for _, c := range cases {
+		actual := escapePattern(c.path)
+		if actual != c.expected {
+			t.Errorf("%q: expected %q, got %q", c.path, c.expected, actual)
+		}
+		if !matchPattern(actual, c.path) {
+			t.Errorf("%q: expected %q to match", c.path, actual)
+		}
+		if c.other != "" && matchPattern(actual, c.other) {
+			t.Errorf("%q: expected %q not to match %q", c.path, actual, c.other)
+		}
+	}
+}
+
+func TestWalkIncludes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stash-filter-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, file := range []string{"a/app.yaml", "a/b.db", "c/d/e.db", "f.txt", "g/h.txt"} {
+		path := filepath.Join(dir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(file), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := []struct {
+		name     string
+		patterns []string
+		included bool
+		excludes []string // relative to dir
+	}{
+		{
+			name:     "file",
+			patterns: []string{"a/app.yaml"},
+			included: true,
+			excludes: []string{"a/b.db", "c", "f.txt", "g"},
+		},
+		{
+			name:     "directory",
+			patterns: []string{"c"},
+			included: true,
+			excludes: []string{"a", "f.txt", "g"},
+		},
+		{
+			name:     "any depth",
+			patterns: []string{"**/*.db"},
+			included: true,
+			excludes: []string{"a/app.yaml", "f.txt", "g"},
+		},
+		{
+			name:     "multiple patterns",
+			patterns: []string{"*.txt", "a/*.db"},
+			included: true,
+			excludes: []string{"a/app.yaml", "c", "g"}, // *.txt is anchored to dir
+		},
+		{
+			name:     "everything",
+			patterns: []string{"."},
+			included: true,
+		},
+		{
+			name:     "no match",
+			patterns: []string{"x", "a/*.txt"},
+			included: false,
+		},
+	}
+	for _, c := range cases {
+		patterns := make([]string, 0, len(c.patterns))
+		for _, pattern := range c.patterns {
+			patterns = append(patterns, anchorPattern(dir, pattern))
+		}
+		included, excludes, err := walkIncludes(dir, patterns)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", c.name, err)
+			continue
+		}
+		if included != c.included {
+			t.Errorf("%s: expected included %v, got %v", c.name, c.included, included)
+			continue
+		}
+		if !included {
+			continue
+		}
+		var expected []string
+		for _, path := range c.excludes {
+			expected = append(expected, escapePattern(filepath.Join(dir, path)))
+		}
+		if !reflect.DeepEqual(expected, excludes) {
+			t.Errorf("%s: expected excludes %v, got %v", c.name, expected, excludes)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/appscode/go/log"
+)
+
+// Lock is a lock held by a restic process on a repository.
+type Lock struct {
+	ID        string    `json:"-"`
+	Time      time.Time `json:"time"`
+	Exclusive bool      `json:"exclusive"`
+	// Hostname of the holder. It is the name of the pod for restic run by Stash,
+	// unless the pod uses host network.
+	Hostname string `json:"hostname"`
+	PID      int    `json:"pid"`
+}
+
+// StaleLockHandler is used by ResticWrapper to remove locks left behind by killed restic
+// processes, when a command fails because the repository is locked.
+type StaleLockHandler interface {
+	// IsStale checks whether the holder of lock is not running anymore.
+	IsStale(lock Lock) (bool, error)
+	// Removed is called after stale locks were removed.
+	Removed(locks []Lock)
+}
+
+// SetStaleLockHandler enables removal of stale locks. Commands that fail because of a stale
+// lock are run once more after the lock is removed, except commands reading from stdin.
+func (w *ResticWrapper) SetStaleLockHandler(h StaleLockHandler) {
+	w.staleLockHandler = h
+}
+
+// Locks returns the locks of the repository.
+func (w *ResticWrapper) Locks() ([]Lock, error) {
+	out := bytes.NewBuffer(nil)
+	args := w.appendGlobalFlags([]interface{}{"list", "locks", "--no-lock"})
+	if err := w.run(args, nil, out); err != nil {
+		return nil, err
+	}
+
+	locks := make([]Lock, 0)
+	scanner := bufio.NewScanner(out)
+	for scanner.Scan() {
+		id := strings.TrimSpace(scanner.Text())
+		if id == "" {
+			continue
+		}
+		lock := Lock{}
+		args := w.appendGlobalFlags([]interface{}{"cat", "lock", id, "--no-lock"})
+		if err := w.runJSON(args, &lock); err != nil {
+			// lock was removed after it was listed
+			log.Warningf("Failed to read lock %s, reason: %s", id, err)
+			continue
+		}
+		lock.ID = id
+		locks = append(locks, lock)
+	}
+	return locks, scanner.Err()
+}
+
+// removeStaleLocks removes all locks of the repository, if all of them are stale. Restic can't
+// remove a single lock, and "restic unlock" only removes locks older than 30 minutes or held by
+// dead processes on the same host. As "restic unlock --remove-all" also removes locks taken after
+// they were checked, eg. by a maintenance job, locks are listed again right before unlocking and
+// nothing is removed if they changed. It returns true if the locked command can be retried.
+func (w *ResticWrapper) removeStaleLocks() (bool, error) {
+	// commands run here must not remove locks again
+	w2 := *w
+	w2.staleLockHandler = nil
+	locks, err := w2.Locks()
+	if err != nil {
+		return false, err
+	}
+	for _, lock := range locks {
+		stale, err := w.staleLockHandler.IsStale(lock)
+		if err != nil {
+			return false, err
+		}
+		if !stale {
+			log.Infof("Repository is locked by PID %d on %s, which is still running", lock.PID, lock.Hostname)
+			return false, nil
+		}
+	}
+	if len(locks) == 0 {
+		// locks were released meanwhile
+		return true, nil
+	}
+
+	current, err := w2.Locks()
+	if err != nil {
+		return false, err
+	}
+	if !sameLocks(locks, current) {
+		log.Infoln("Locks of repository changed while checking them, skipping removal of stale locks")
+		return false, nil
+	}
+	args := w.appendGlobalFlags([]interface{}{"unlock", "--remove-all"})
+	if err = w2.run(args, nil, nil); err != nil {
+		return false, err
+	}
+	w.staleLockHandler.Removed(locks)
+	return true, nil
+}
+
+// sameLocks checks whether a and b contain the same locks.
+func sameLocks(a, b []Lock) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	ids := make(map[string]bool, len(a))
+	for _, lock := range a {
+		ids[lock.ID] = true
+	}
+	for _, lock := range b {
+		if !ids[lock.ID] {
+			return false
+		}
+	}
+	return true
+}
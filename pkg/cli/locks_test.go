@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"testing"
+)
+
+func TestSameLocks(t *testing.T) {
+	lock := func(id string) Lock {
+		return Lock{ID: id, Hostname: "host-0", PID: 42}
+	}
+	cases := []struct {
+		name     string
+		a        []Lock
+		b        []Lock
+		expected bool
+	}{
+		{"none", nil, []Lock{}, true},
+		{"same", []Lock{lock("a1"), lock("b2")}, []Lock{lock("a1"), lock("b2")}, true},
+		{"different order", []Lock{lock("a1"), lock("b2")}, []Lock{lock("b2"), lock("a1")}, true},
+		{"lock added", []Lock{lock("a1")}, []Lock{lock("a1"), lock("b2")}, false},
+		{"lock removed", []Lock{lock("a1"), lock("b2")}, []Lock{lock("a1")}, false},
+		{"lock replaced", []Lock{lock("a1"), lock("b2")}, []Lock{lock("a1"), lock("c3")}, false},
+	}
+	for _, c := range cases {
+		if actual := sameLocks(c.a, c.b); actual != c.expected {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/appscode/go/log"
+)
+
+const progressLogInterval = 30 * time.Second
+
+// BackupSummary is the last message printed by restic backup with --json flag.
+type BackupSummary struct {
+	FilesNew            int64   `json:"files_new"`
+	FilesChanged        int64   `json:"files_changed"`
+	FilesUnmodified     int64   `json:"files_unmodified"`
+	DirsNew             int64   `json:"dirs_new"`
+	DirsChanged         int64   `json:"dirs_changed"`
+	DirsUnmodified      int64   `json:"dirs_unmodified"`
+	DataAdded           int64   `json:"data_added"`
+	TotalFilesProcessed int64   `json:"total_files_processed"`
+	TotalBytesProcessed int64   `json:"total_bytes_processed"`
+	TotalDuration       float64 `json:"total_duration"`
+	SnapshotID          string  `json:"snapshot_id"`
+}
+
+// BackupStatus is printed periodically by restic backup with --json flag while backup is running.
+type BackupStatus struct {
+	SecondsElapsed int64   `json:"seconds_elapsed"`
+	PercentDone    float64 `json:"percent_done"`
+	TotalFiles     int64   `json:"total_files"`
+	FilesDone      int64   `json:"files_done"`
+	TotalBytes     int64   `json:"total_bytes"`
+	BytesDone      int64   `json:"bytes_done"`
+}
+
+// backupOutput parses the messages printed by restic backup --json as they are written.
+// Progress is logged every progressLogInterval and the summary is kept.
+type backupOutput struct {
+	path    string
+	buf     []byte
+	summary *BackupSummary
+	lastLog time.Time
+}
+
+func newBackupOutput(path string) *backupOutput {
+	return &backupOutput{
+		path:    path,
+		lastLog: time.Now(),
+	}
+}
+
+func (o *backupOutput) Write(p []byte) (int, error) {
+	o.buf = append(o.buf, p...)
+	for {
+		i := bytes.IndexByte(o.buf, '\n')
+		if i < 0 {
+			break
+		}
+		o.parseLine(o.buf[:i])
+		o.buf = o.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+func (o *backupOutput) parseLine(line []byte) {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 || line[0] != '{' {
+		return
+	}
+	msg := struct {
+		MessageType string `json:"message_type"`
+	}{}
+	if err := json.Unmarshal(line, &msg); err != nil {
+		return
+	}
+	switch msg.MessageType {
+	case "status":
+		if time.Since(o.lastLog) < progressLogInterval {
+			return
+		}
+		status := BackupStatus{}
+		if err := json.Unmarshal(line, &status); err == nil {
+			o.lastLog = time.Now()
+			log.Infof("Backup of %s is %.1f%% done, %d of %d files, %d of %d bytes\n",
+				o.path, status.PercentDone*100, status.FilesDone, status.TotalFiles, status.BytesDone, status.TotalBytes)
+		}
+	case "summary":
+		summary := &BackupSummary{}
+		if err := json.Unmarshal(line, summary); err == nil {
+			o.summary = summary
+		}
+	}
+}
+
+// Summary returns the summary of a successful backup. Backup is not failed if the summary
+// can't be read, it is only missing from the backup history and metrics.
+func (o *backupOutput) Summary() *BackupSummary {
+	// last message may not end with a newline
+	o.parseLine(o.buf)
+	o.buf = nil
+	if o.summary == nil {
+		log.Warningf("Failed to read summary of backup of %s\n", o.path)
+	}
+	return o.summary
+}
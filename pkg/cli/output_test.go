@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+)
+
+// output of restic 0.12.0 backup --json
+const (
+	statusLine0 = `{"message_type":"status","percent_done":0,"total_files":1,"total_bytes":18}`
+	statusLine1 = `{"message_type":"status","seconds_elapsed":1,"percent_done":0.5,"total_files":3,"files_done":1,"total_bytes":36,"bytes_done":18,"current_files":["/source/data/b.txt"]}`
+	summaryLine = `{"message_type":"summary","files_new":3,"files_changed":1,"files_unmodified":2,"dirs_new":1,"dirs_changed":0,"dirs_unmodified":1,"data_blobs":3,"tree_blobs":2,"data_added":1131,"total_files_processed":6,"total_bytes_processed":36,"total_duration":0.216412537,"snapshot_id":"e1e4a8dc2b7a0c1e1d8f7f6e05b7d3a1c9e4f2b6d8a0c3e5f7a9b1d3e5f7a9b1"}`
+)
+
+func TestBackupOutput(t *testing.T) {
+	summary := &BackupSummary{
+		FilesNew:            3,
+		FilesChanged:        1,
+		FilesUnmodified:     2,
+		DirsNew:             1,
+		DirsUnmodified:      1,
+		DataAdded:           1131,
+		TotalFilesProcessed: 6,
+		TotalBytesProcessed: 36,
+		TotalDuration:       0.216412537,
+		SnapshotID:          "e1e4a8dc2b7a0c1e1d8f7f6e05b7d3a1c9e4f2b6d8a0c3e5f7a9b1d3e5f7a9b1",
+	}
+	cases := []struct {
+		name      string
+		output    string
+		chunkSize int // 0 writes output at once
+		expected  *BackupSummary
+	}{
+		{
+			name:     "status and summary",
+			output:   statusLine0 + "\n" + statusLine1 + "\n" + summaryLine + "\n",
+			expected: summary,
+		},
+		{
+			name:      "split writes",
+			output:    statusLine0 + "\n" + statusLine1 + "\n" + summaryLine + "\n",
+			chunkSize: 7,
+			expected:  summary,
+		},
+		{
+			name:     "summary without newline",
+			output:   statusLine1 + "\n" + summaryLine,
+			expected: summary,
+		},
+		{
+			name:     "other lines",
+			output:   "\n  \nusing parent snapshot 1fc9be0d\n{not json}\n" + summaryLine + "\r\n",
+			expected: summary,
+		},
+		{
+			name:   "no summary",
+			output: statusLine0 + "\n" + statusLine1 + "\n",
+		},
+		{
+			name:   "truncated summary",
+			output: statusLine1 + "\n" + summaryLine[:len(summaryLine)/2],
+		},
+	}
+	for _, c := range cases {
+		out := newBackupOutput("/source/data")
+		data := []byte(c.output)
+		size := c.chunkSize
+		if size == 0 {
+			size = len(data)
+		}
+		for len(data) > 0 {
+			n := size
+			if n > len(data) {
+				n = len(data)
+			}
+			if written, err := out.Write(data[:n]); err != nil || written != n {
+				t.Fatalf("%s: expected %d bytes written, got %d, error: %v", c.name, n, written, err)
+			}
+			data = data[n:]
+		}
+		if actual := out.Summary(); !reflect.DeepEqual(c.expected, actual) {
+			t.Errorf("%s: expected %+v, got %+v", c.name, c.expected, actual)
+		}
+	}
+}
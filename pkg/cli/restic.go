@@ -1,12 +1,20 @@
 package cli
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/appscode/go/log"
 	api "github.com/appscode/stash/apis/stash/v1alpha1"
-	shell "github.com/codeskyblue/go-sh"
 )
 
 const (
@@ -14,22 +22,36 @@ const (
 )
 
 type ResticWrapper struct {
-	sh          *shell.Session
+	runner      Runner
+	ctx         context.Context
+	env         map[string]string
 	scratchDir  string
 	enableCache bool
 	hostname    string
+	cacertFile  string
+	insecureTLS bool
+	// extended options passed to restic using -o flag
+	extendedOptions  []string
+	bandwidth        api.BandwidthLimit
+	staleLockHandler StaleLockHandler
 }
 
 func New(scratchDir string, enableCache bool, hostname string) *ResticWrapper {
-	ctrl := &ResticWrapper{
-		sh:          shell.NewSession(),
+	return &ResticWrapper{
+		runner:      ExecRunner{Path: Exe},
+		ctx:         context.Background(),
+		env:         map[string]string{},
 		scratchDir:  scratchDir,
 		enableCache: enableCache,
 		hostname:    hostname,
 	}
-	ctrl.sh.SetDir(scratchDir)
-	ctrl.sh.ShowCMD = true
-	return ctrl
+}
+
+// WithContext returns a copy of w whose restic commands are canceled when ctx is done.
+func (w *ResticWrapper) WithContext(ctx context.Context) *ResticWrapper {
+	w2 := *w
+	w2.ctx = ctx
+	return &w2
 }
 
 type Snapshot struct {
@@ -41,26 +63,45 @@ type Snapshot struct {
 	Username string    `json:"username"`
 	UID      int       `json:"uid"`
 	Gid      int       `json:"gid"`
+	Tags     []string  `json:"tags"`
 }
 
 func (w *ResticWrapper) ListSnapshots() ([]Snapshot, error) {
 	result := make([]Snapshot, 0)
-	args := w.appendCacheDirFlag([]interface{}{"snapshots", "--json"})
-	err := w.sh.Command(Exe, args...).UnmarshalJSON(&result)
+	args := w.appendGlobalFlags([]interface{}{"snapshots", "--json"})
+	err := w.runJSON(args, &result)
+	return result, err
+}
+
+type RepositoryStats struct {
+	TotalSize      int64 `json:"total_size"`
+	TotalFileCount int64 `json:"total_file_count"`
+}
+
+// Stats returns the size of data stored in the repository.
+func (w *ResticWrapper) Stats() (*RepositoryStats, error) {
+	result := &RepositoryStats{}
+	args := w.appendGlobalFlags([]interface{}{"stats", "--json", "--mode", "raw-data"})
+	err := w.runJSON(args, result)
 	return result, err
 }
 
+// InitRepositoryIfAbsent initializes the repository, unless it exists or can't be accessed
+// for a reason other than missing repository, eg, a wrong password.
 func (w *ResticWrapper) InitRepositoryIfAbsent() error {
-	args := w.appendCacheDirFlag([]interface{}{"snapshots", "--json"})
-	if err := w.sh.Command(Exe, args...).Run(); err != nil {
-		args = w.appendCacheDirFlag([]interface{}{"init"})
-		return w.sh.Command(Exe, args...).Run()
+	args := w.appendGlobalFlags([]interface{}{"snapshots", "--json"})
+	if err := w.run(args, nil, nil); err != nil {
+		if IsWrongPassword(err) || IsLocked(err) || IsCanceled(err) {
+			return err
+		}
+		args = w.appendGlobalFlags([]interface{}{"init"})
+		return w.run(args, nil, nil)
 	}
 	return nil
 }
 
-func (w *ResticWrapper) Backup(resource *api.Restic, fg api.FileGroup) error {
-	args := []interface{}{"backup", fg.Path, "--force"}
+func (w *ResticWrapper) Backup(resource *api.Restic, fg api.FileGroup) (*BackupSummary, error) {
+	args := []interface{}{"backup", fg.Path, "--force", "--json"}
 	if w.hostname != "" {
 		args = append(args, "--hostname")
 		args = append(args, w.hostname)
@@ -70,11 +111,79 @@ func (w *ResticWrapper) Backup(resource *api.Restic, fg api.FileGroup) error {
 		args = append(args, "--tag")
 		args = append(args, tag)
 	}
-	args = w.appendCacheDirFlag(args)
-	return w.sh.Command(Exe, args...).Run()
+	filters, cleanup, err := w.filterArgs(fg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply includes of %s, reason: %s", fg.Path, err)
+	}
+	defer cleanup()
+	args = append(args, filters...)
+	args = w.withBandwidthLimit(fg.Bandwidth).appendGlobalFlags(args)
+
+	out := newBackupOutput(fg.Path)
+	if err := w.run(args, nil, out); err != nil {
+		return nil, err
+	}
+	return out.Summary(), nil
+}
+
+// BackupFromStdin backs up the data written by produce as a file named fg.Path. If produce
+// fails, restic is killed before it reads EOF, so that no snapshot is created with partial data.
+// The context passed to produce is canceled if the context of w is done or restic exits,
+// so produce must stop writing once it is done.
+func (w *ResticWrapper) BackupFromStdin(fg api.FileGroup, produce func(context.Context, io.Writer) error) (*BackupSummary, error) {
+	args := []interface{}{"backup", "--stdin", "--stdin-filename", fg.Path, "--json"}
+	if w.hostname != "" {
+		args = append(args, "--hostname")
+		args = append(args, w.hostname)
+	}
+	// add tags if any
+	for _, tag := range fg.Tags {
+		args = append(args, "--tag")
+		args = append(args, tag)
+	}
+	args = w.withBandwidthLimit(fg.Bandwidth).appendGlobalFlags(args)
+
+	// An os.Pipe is used, so that restic can't see EOF until the write end is closed.
+	stdin, stdinWriter, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(w.ctx)
+	defer cancel()
+	out := newBackupOutput(fg.Path)
+	done := make(chan error, 1)
+	go func() {
+		err := w.runner.Run(ctx, w.command(args, stdin, out))
+		// writes fail from now on, instead of blocking produce
+		stdin.Close()
+		cancel()
+		done <- err
+	}()
+
+	if err = produce(ctx, stdinWriter); err != nil {
+		cancel()
+		<-done
+		stdinWriter.Close()
+		return nil, err
+	}
+	if err = stdinWriter.Close(); err != nil {
+		cancel()
+		<-done
+		return nil, err
+	}
+	if err = <-done; err != nil {
+		return nil, err
+	}
+	return out.Summary(), nil
 }
 
 func (w *ResticWrapper) Forget(resource *api.Restic, fg api.FileGroup) error {
+	// Snapshots can't be removed from an append-only repository
+	if resource.Spec.Backend.Rest != nil && resource.Spec.Backend.Rest.AppendOnly {
+		log.Infof("Skipping forget for fileGroup %s, rest server is in append-only mode\n", fg.Path)
+		return nil
+	}
+
 	// Get retentionPolicy for fileGroup, ignore if not found
 	retentionPolicy := api.RetentionPolicy{}
 	for _, policy := range resource.Spec.RetentionPolicies {
@@ -113,35 +222,304 @@ func (w *ResticWrapper) Forget(resource *api.Restic, fg api.FileGroup) error {
 		args = append(args, string(api.KeepTag))
 		args = append(args, tag)
 	}
-	if retentionPolicy.Prune {
+	if retentionPolicy.KeepWithin != "" {
+		args = append(args, string(api.KeepWithin))
+		args = append(args, retentionPolicy.KeepWithin)
+	}
+	// With scheduled prune, data is only removed by the maintenance job, so that the repository
+	// is not locked exclusively after every backup.
+	if retentionPolicy.Prune && (resource.Spec.Maintenance == nil || resource.Spec.Maintenance.Prune == nil) {
 		args = append(args, "--prune")
 	}
 	if retentionPolicy.DryRun {
 		args = append(args, "--dry-run")
 	}
-	if len(args) > 1 {
-		args = w.appendCacheDirFlag(args)
-		return w.sh.Command(Exe, args...).Run()
+	if len(args) == 1 {
+		return nil
 	}
-	return nil
+
+	if len(retentionPolicy.GroupBy) > 0 {
+		groupBy := make([]string, 0, len(retentionPolicy.GroupBy))
+		for _, g := range retentionPolicy.GroupBy {
+			groupBy = append(groupBy, string(g))
+		}
+		args = append(args, "--group-by")
+		args = append(args, strings.Join(groupBy, ","))
+	}
+	// The repository may be shared by other fileGroups and hosts, which use their own policies.
+	args = append(args, "--path")
+	args = append(args, filepath.Clean(fg.Path))
+	hostname := w.hostname
+	if hostname == "" {
+		// restic backup uses the hostname of the machine by default
+		hostname, _ = os.Hostname()
+	}
+	if hostname != "" {
+		args = append(args, "--host")
+		args = append(args, hostname)
+	}
+	args = w.appendGlobalFlags(args)
+	return w.run(args, nil, nil)
 }
 
-func (w *ResticWrapper) Restore(path, host string) error {
+// FindSnapshot returns the ID of the newest snapshot of path taken by host at or before the given time.
+// If tags are specified, only snapshots having all of these tags are considered.
+func (w *ResticWrapper) FindSnapshot(path, host string, tags []string, before time.Time) (string, error) {
+	args := []interface{}{"snapshots", "--json"}
+	args = append(args, "--path")
+	args = append(args, path)
+	args = append(args, "--host")
+	args = append(args, host)
+	if len(tags) > 0 {
+		args = append(args, "--tag")
+		args = append(args, strings.Join(tags, ","))
+	}
+	args = w.appendGlobalFlags(args)
+
+	result := make([]Snapshot, 0)
+	if err := w.runJSON(args, &result); err != nil {
+		return "", err
+	}
+	var found *Snapshot
+	for i := range result {
+		if result[i].Time.After(before) {
+			continue
+		}
+		if found == nil || result[i].Time.After(found.Time) {
+			found = &result[i]
+		}
+	}
+	if found == nil {
+		return "", fmt.Errorf("no snapshot found for path %s and host %s taken before %s", path, host, before.Format(time.RFC3339))
+	}
+	return found.ID, nil
+}
+
+// Restore restores the contents of target.Path from the given snapshot into target.TargetPath.
+// If snapshotID is empty, the latest snapshot having all of the tags is restored.
+//
+// Restic recreates the full path of the restored files under its target directory. So files are
+// restored into a temporary directory created in stagingDir, and the contents of target.Path are
+// moved from there into target.TargetPath. stagingDir must be on the same filesystem as
+// target.TargetPath, eg. the recovered volume containing it.
+func (w *ResticWrapper) Restore(host, snapshotID string, tags []string, target api.RestoreTarget, stagingDir string) error {
+	tmpDir, err := ioutil.TempDir(stagingDir, ".stash-restore-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	args := w.appendGlobalFlags(restoreArgs(host, snapshotID, tags, target, tmpDir))
+	if err = w.run(args, nil, nil); err != nil {
+		return err
+	}
+	restored := filepath.Join(tmpDir, filepath.Clean(target.Path))
+	if _, err = os.Lstat(restored); os.IsNotExist(err) {
+		// nothing matched the include and exclude patterns
+		return nil
+	}
+	return moveTree(restored, filepath.Clean(target.TargetPath))
+}
+
+func restoreArgs(host, snapshotID string, tags []string, target api.RestoreTarget, dir string) []interface{} {
+	if snapshotID == "" {
+		snapshotID = "latest"
+	}
 	args := []interface{}{"restore"}
-	args = append(args, "latest") // TODO @ Dipta: Add support for specific snapshotID
+	args = append(args, snapshotID)
 	args = append(args, "--path")
-	args = append(args, path) // source-path specified in restic fileGroup
+	args = append(args, target.Path) // source-path specified in restic fileGroup
 	args = append(args, "--host")
 	args = append(args, host)
+	if len(tags) > 0 {
+		args = append(args, "--tag")
+		args = append(args, strings.Join(tags, ","))
+	}
 	args = append(args, "--target")
-	args = append(args, path) // restore in same path as source-path
-	args = w.appendCacheDirFlag(args)
-	return w.sh.Command(Exe, args...).Run()
+	args = append(args, dir)
+	// relative patterns are anchored to target.Path, like includes of fileGroups are anchored
+	// to their path, instead of matching at any depth
+	for _, pattern := range target.Include {
+		args = append(args, "--include")
+		args = append(args, anchorPattern(target.Path, pattern))
+	}
+	for _, pattern := range target.Exclude {
+		args = append(args, "--exclude")
+		args = append(args, anchorPattern(target.Path, pattern))
+	}
+	return args
+}
+
+// moveTree moves src to dst. If both are directories, the entries of src are moved into dst
+// recursively, replacing the existing entries of dst like restic restore does.
+func moveTree(src, dst string) error {
+	srcInfo, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+	dstInfo, err := os.Lstat(dst)
+	if os.IsNotExist(err) {
+		if err = os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return err
+		}
+		return os.Rename(src, dst)
+	} else if err != nil {
+		return err
+	}
+	if !srcInfo.IsDir() || !dstInfo.IsDir() {
+		if err = os.RemoveAll(dst); err != nil {
+			return err
+		}
+		return os.Rename(src, dst)
+	}
+
+	entries, err := ioutil.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err = moveTree(filepath.Join(src, entry.Name()), filepath.Join(dst, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return os.Chmod(dst, srcInfo.Mode().Perm())
+}
+
+// Dump writes the content of file path from the given snapshot to out. If snapshotID
+// is empty, the latest snapshot having all of the tags is used.
+func (w *ResticWrapper) Dump(host, snapshotID string, tags []string, path string, out io.Writer) error {
+	if snapshotID == "" {
+		snapshotID = "latest"
+	}
+	args := []interface{}{"dump", snapshotID, path}
+	args = append(args, "--path")
+	args = append(args, path)
+	args = append(args, "--host")
+	args = append(args, host)
+	if len(tags) > 0 {
+		args = append(args, "--tag")
+		args = append(args, strings.Join(tags, ","))
+	}
+	args = w.appendGlobalFlags(args)
+	return w.run(args, nil, out)
+}
+
+// Check checks the integrity of the repository. If readDataSubset is not empty, eg. 1/5, that subset
+// of data is also read and verified.
+func (w *ResticWrapper) Check(readDataSubset string) error {
+	args := []interface{}{"check"}
+	if readDataSubset != "" {
+		args = append(args, "--read-data-subset="+readDataSubset)
+	}
+	args = w.appendGlobalFlags(args)
+	return w.run(args, nil, nil)
+}
+
+// Prune removes data that is not referenced by any snapshot.
+func (w *ResticWrapper) Prune() error {
+	args := w.appendGlobalFlags([]interface{}{"prune"})
+	return w.run(args, nil, nil)
+}
+
+// Unlock removes stale locks. Locks of running restic processes are kept.
+func (w *ResticWrapper) Unlock() error {
+	args := w.appendGlobalFlags([]interface{}{"unlock"})
+	return w.run(args, nil, nil)
+}
+
+func (w *ResticWrapper) RebuildIndex() error {
+	args := w.appendGlobalFlags([]interface{}{"rebuild-index"})
+	return w.run(args, nil, nil)
 }
 
-func (w *ResticWrapper) Check() error {
-	args := w.appendCacheDirFlag([]interface{}{"check"})
-	return w.sh.Command(Exe, args...).Run()
+// command returns a restic command using the environment of w.
+func (w *ResticWrapper) command(args []interface{}, stdin io.Reader, stdout io.Writer) Command {
+	strArgs := make([]string, 0, len(args))
+	for _, arg := range args {
+		strArgs = append(strArgs, fmt.Sprint(arg))
+	}
+	env := make(map[string]string, len(w.env))
+	for k, v := range w.env {
+		env[k] = v
+	}
+	return Command{
+		Args:   strArgs,
+		Env:    env,
+		Dir:    w.scratchDir,
+		Stdin:  stdin,
+		Stdout: stdout,
+	}
+}
+
+func (w *ResticWrapper) run(args []interface{}, stdin io.Reader, stdout io.Writer) error {
+	err := w.runner.Run(w.ctx, w.command(args, stdin, stdout))
+	if !IsLocked(err) || w.staleLockHandler == nil || stdin != nil {
+		return err
+	}
+	retry, e2 := w.removeStaleLocks()
+	if e2 != nil {
+		log.Errorf("Failed to remove stale locks, reason: %s", e2)
+	}
+	if !retry {
+		return err
+	}
+	return w.runner.Run(w.ctx, w.command(args, stdin, stdout))
+}
+
+// runJSON runs restic and decodes its output into v.
+func (w *ResticWrapper) runJSON(args []interface{}, v interface{}) error {
+	out := bytes.NewBuffer(nil)
+	if err := w.run(args, nil, out); err != nil {
+		return err
+	}
+	return json.NewDecoder(out).Decode(v)
+}
+
+func (w *ResticWrapper) setEnv(key, value string) {
+	w.env[key] = value
+}
+
+// SetBandwidthLimit sets the bandwidth limits of restic commands. For each of upload and
+// download, the first non-zero limit is used, so limits are passed in order of precedence.
+func (w *ResticWrapper) SetBandwidthLimit(limits ...*api.BandwidthLimit) {
+	w.bandwidth = api.BandwidthLimit{}
+	for _, limit := range limits {
+		if limit == nil {
+			continue
+		}
+		if w.bandwidth.Upload == 0 {
+			w.bandwidth.Upload = limit.Upload
+		}
+		if w.bandwidth.Download == 0 {
+			w.bandwidth.Download = limit.Download
+		}
+	}
+}
+
+// withBandwidthLimit returns a copy of w whose bandwidth limits are overridden by limit.
+func (w *ResticWrapper) withBandwidthLimit(limit *api.BandwidthLimit) *ResticWrapper {
+	w2 := *w
+	w2.SetBandwidthLimit(limit, &w.bandwidth)
+	return &w2
+}
+
+// appendGlobalFlags appends the flags common to all restic commands.
+func (w *ResticWrapper) appendGlobalFlags(args []interface{}) []interface{} {
+	args = w.appendCacheDirFlag(args)
+	args = w.appendCaCertFlag(args)
+	if w.insecureTLS {
+		args = append(args, "--insecure-tls")
+	}
+	if w.bandwidth.Upload > 0 {
+		args = append(args, "--limit-upload", w.bandwidth.Upload)
+	}
+	if w.bandwidth.Download > 0 {
+		args = append(args, "--limit-download", w.bandwidth.Download)
+	}
+	for _, opt := range w.extendedOptions {
+		args = append(args, "-o", opt)
+	}
+	return args
 }
 
 func (w *ResticWrapper) appendCacheDirFlag(args []interface{}) []interface{} {
@@ -151,3 +529,10 @@ func (w *ResticWrapper) appendCacheDirFlag(args []interface{}) []interface{} {
 	}
 	return append(args, "--no-cache")
 }
+
+func (w *ResticWrapper) appendCaCertFlag(args []interface{}) []interface{} {
+	if w.cacertFile != "" {
+		return append(args, "--cacert", w.cacertFile)
+	}
+	return args
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	api "github.com/appscode/stash/apis/stash/v1alpha1"
+)
+
+func TestRestoreArgs(t *testing.T) {
+	cases := []struct {
+		name       string
+		snapshotID string
+		tags       []string
+		target     api.RestoreTarget
+		expected   []string
+	}{
+		{
+			name:     "latest",
+			target:   api.RestoreTarget{Path: "/source/data", TargetPath: "/restore/data"},
+			expected: []string{"restore", "latest", "--path", "/source/data", "--host", "host-0", "--target", "/restore/.stash-restore-1"},
+		},
+		{
+			name:       "snapshot with tags",
+			snapshotID: "a1b2c3d4",
+			tags:       []string{"daily", "db"},
+			target:     api.RestoreTarget{Path: "/source/data", TargetPath: "/source/data"},
+			expected:   []string{"restore", "a1b2c3d4", "--path", "/source/data", "--host", "host-0", "--tag", "daily,db", "--target", "/restore/.stash-restore-1"},
+		},
+		{
+			name: "relative patterns",
+			target: api.RestoreTarget{
+				Path:       "/source/data",
+				TargetPath: "/restore/data",
+				Include:    []string{"config", "**/*.db"},
+			},
+			expected: []string{"restore", "latest", "--path", "/source/data", "--host", "host-0", "--target", "/restore/.stash-restore-1",
+				"--include", "/source/data/config", "--include", "/source/data/**/*.db"},
+		},
+		{
+			name: "include and exclude",
+			target: api.RestoreTarget{
+				Path:       "/source/data",
+				TargetPath: "/restore/data",
+				Include:    []string{"/source/data/config"},
+				Exclude:    []string{"/source/data/config/*.tmp"},
+			},
+			expected: []string{"restore", "latest", "--path", "/source/data", "--host", "host-0", "--target", "/restore/.stash-restore-1",
+				"--include", "/source/data/config", "--exclude", "/source/data/config/*.tmp"},
+		},
+	}
+	for _, c := range cases {
+		args := restoreArgs("host-0", c.snapshotID, c.tags, c.target, "/restore/.stash-restore-1")
+		actual := make([]string, 0, len(args))
+		for _, arg := range args {
+			actual = append(actual, fmt.Sprint(arg))
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: expected %q, got %q", c.name, c.expected, actual)
+		}
+	}
+}
+
+func TestMoveTree(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stash-move-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	write := func(path, content string) {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// restic restores /source/data into <tmp>/source/data
+	write("tmp/source/data/a", "new a")
+	write("tmp/source/data/sub/b", "new b")
+	write("restore/data/a", "old a")
+	write("restore/data/sub/c", "old c")
+
+	if err = moveTree(filepath.Join(dir, "tmp/source/data"), filepath.Join(dir, "restore/data")); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		"restore/data/a":     "new a",
+		"restore/data/sub/b": "new b",
+		"restore/data/sub/c": "old c",
+	}
+	for path, content := range expected {
+		data, err := ioutil.ReadFile(filepath.Join(dir, path))
+		if err != nil {
+			t.Errorf("%s: %s", path, err)
+		} else if string(data) != content {
+			t.Errorf("%s: expected %q, got %q", path, content, data)
+		}
+	}
+	if _, err = os.Stat(filepath.Join(dir, "restore/data/source")); !os.IsNotExist(err) {
+		t.Errorf("source path is recreated under target path")
+	}
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/appscode/go/log"
+)
+
+// Runner runs restic commands built by ResticWrapper. It allows restic to be run other than
+// by executing the restic binary. ExecRunner is the only implementation at the moment, since
+// restic does not export its packages to be used as a library.
+type Runner interface {
+	// Run runs cmd until it completes or ctx is done. Errors reported by restic are returned as *Error.
+	Run(ctx context.Context, cmd Command) error
+}
+
+// Command is a restic command, eg, backup or snapshots, with its flags.
+type Command struct {
+	Args []string
+	// Env is added to the environment of the operator.
+	Env map[string]string
+	Dir string
+	// Stdin is optional. Stdout defaults to os.Stdout.
+	Stdin  io.Reader
+	Stdout io.Writer
+}
+
+// ExecRunner runs the restic binary at Path.
+type ExecRunner struct {
+	Path string
+}
+
+var _ Runner = ExecRunner{}
+
+func (r ExecRunner) Run(ctx context.Context, c Command) error {
+	cmd := exec.CommandContext(ctx, r.Path, c.Args...)
+	cmd.Dir = c.Dir
+	cmd.Env = os.Environ()
+	for k, v := range c.Env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	cmd.Stdin = c.Stdin
+	cmd.Stdout = c.Stdout
+	if cmd.Stdout == nil {
+		cmd.Stdout = os.Stdout
+	}
+	stderr := &messageWriter{}
+	cmd.Stderr = io.MultiWriter(os.Stderr, stderr)
+
+	log.Infoln(r.Path, strings.Join(c.Args, " "))
+	if err := cmd.Run(); err != nil {
+		return newError(ctx, err, stderr.String())
+	}
+	return nil
+}
+
+// messageWriter keeps the message printed by restic for a failure. Restic prints the reason
+// in the last line of stderr, or in the last few lines starting with "Fatal:", eg. for a
+// locked repository the holder of the lock is printed in the lines following the reason.
+type messageWriter struct {
+	lines   []string
+	current []byte
+}
+
+const messageMaxLines = 8
+
+func (w *messageWriter) Write(p []byte) (int, error) {
+	for _, b := range p {
+		if b == '\n' {
+			w.flush()
+		} else if len(w.current) < 4096 {
+			w.current = append(w.current, b)
+		}
+	}
+	return len(p), nil
+}
+
+func (w *messageWriter) flush() {
+	if line := strings.TrimSpace(string(w.current)); line != "" {
+		w.lines = append(w.lines, line)
+		if len(w.lines) > messageMaxLines {
+			w.lines = w.lines[1:]
+		}
+	}
+	w.current = w.current[:0]
+}
+
+func (w *messageWriter) String() string {
+	w.flush()
+	if len(w.lines) == 0 {
+		return ""
+	}
+	for i := len(w.lines) - 1; i >= 0; i-- {
+		if strings.HasPrefix(w.lines[i], "Fatal:") {
+			return strings.Join(w.lines[i:], ", ")
+		}
+	}
+	return w.lines[len(w.lines)-1]
+}
@@ -62,7 +62,7 @@ func NewCmdBackup() *cobra.Command {
 			}
 			opt.ScratchDir = strings.TrimSuffix(opt.ScratchDir, "/") // make ScratchDir in setup()
 
-			ctrl := backup.New(kubeClient, stashClient, opt)
+			ctrl := backup.New(config, kubeClient, stashClient, opt)
 
 			if opt.RunViaCron {
 				log.Infoln("Running backup periodically via cron")
@@ -87,8 +87,10 @@ func NewCmdBackup() *cobra.Command {
 	cmd.Flags().StringVar(&opt.PushgatewayURL, "pushgateway-url", opt.PushgatewayURL, "URL of Prometheus pushgateway used to cache backup metrics")
 	cmd.Flags().DurationVar(&opt.ResyncPeriod, "resync-period", opt.ResyncPeriod, "If non-zero, will re-list this often. Otherwise, re-list will be delayed aslong as possible (until the upstream source closes the watch or times out.")
 	cmd.Flags().BoolVar(&opt.RunViaCron, "run-via-cron", opt.RunViaCron, "Run backup periodically via cron.")
-	cmd.Flags().StringVar(&opt.ImageTag, "image-tag", opt.ImageTag, "Check job image tag.")
-	cmd.Flags().BoolVar(&opt.EnableRBAC, "enable-rbac", opt.EnableRBAC, "Enable RBAC")
+	cmd.Flags().StringVar(&opt.ImageTag, "image-tag", opt.ImageTag, "Deprecated, check jobs are replaced by maintenance jobs of the operator.")
+	cmd.Flags().BoolVar(&opt.EnableRBAC, "enable-rbac", opt.EnableRBAC, "Deprecated, check jobs are replaced by maintenance jobs of the operator.")
+	addBandwidthLimitFlags(cmd.Flags())
+	addConcurrencyLimitFlags(cmd.Flags())
 
 	return cmd
 }
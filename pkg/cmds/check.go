@@ -43,6 +43,7 @@ func NewCmdCheck() *cobra.Command {
 	cmd.Flags().StringVar(&opt.ResticName, "restic-name", opt.ResticName, "Name of the Restic CRD.")
 	cmd.Flags().StringVar(&opt.HostName, "host-name", opt.HostName, "Host name for workload.")
 	cmd.Flags().StringVar(&opt.SmartPrefix, "smart-prefix", opt.SmartPrefix, "Smart prefix for workload")
+	addBandwidthLimitFlags(cmd.Flags())
 
 	return cmd
 }
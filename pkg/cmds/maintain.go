@@ -0,0 +1,52 @@
+package cmds
+
+import (
+	"github.com/appscode/go/log"
+	"github.com/appscode/kutil/meta"
+	api "github.com/appscode/stash/apis/stash/v1alpha1"
+	cs "github.com/appscode/stash/client/typed/stash/v1alpha1"
+	"github.com/appscode/stash/pkg/maintenance"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func NewCmdMaintain() *cobra.Command {
+	var (
+		masterURL      string
+		kubeconfigPath string
+		task           string
+		opt            = maintenance.Options{
+			Namespace: meta.Namespace(),
+		}
+	)
+
+	cmd := &cobra.Command{
+		Use:               "maintain",
+		Short:             "Run maintenance task of restic repository",
+		DisableAutoGenTag: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			config, err := clientcmd.BuildConfigFromFlags(masterURL, kubeconfigPath)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			kubeClient := kubernetes.NewForConfigOrDie(config)
+			stashClient := cs.NewForConfigOrDie(config)
+
+			opt.Task = api.MaintenanceTask(task)
+			c := maintenance.New(kubeClient, stashClient, opt)
+			if err = c.Run(); err != nil {
+				log.Fatal(err)
+			}
+			log.Infoln("Exiting stash maintain")
+		},
+	}
+	cmd.Flags().StringVar(&masterURL, "master", masterURL, "The address of the Kubernetes API server (overrides any value in kubeconfig)")
+	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", kubeconfigPath, "Path to kubeconfig file with authorization information (the master location is set by the master flag).")
+	cmd.Flags().StringVar(&opt.RepositoryName, "repository", opt.RepositoryName, "Name of the Repository CRD.")
+	cmd.Flags().StringVar(&task, "task", task, "Maintenance task, one of check, prune, unlock and rebuild-index.")
+	cmd.Flags().StringVar(&opt.ReadDataSubset, "read-data-subset", opt.ReadDataSubset, "Subset of data read by check, eg. 1/5")
+	addBandwidthLimitFlags(cmd.Flags())
+
+	return cmd
+}
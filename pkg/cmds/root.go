@@ -53,5 +53,18 @@ func NewRootCmd() *cobra.Command {
 	rootCmd.AddCommand(NewCmdBackup())
 	rootCmd.AddCommand(NewCmdRecover())
 	rootCmd.AddCommand(NewCmdCheck())
+	rootCmd.AddCommand(NewCmdMaintain())
 	return rootCmd
 }
+
+// addBandwidthLimitFlags adds flags for the default bandwidth limits of restic. The operator passes
+// its defaults to the stash commands run in pods using the same flags.
+func addBandwidthLimitFlags(fs *pflag.FlagSet) {
+	fs.Int32Var(&util.DefaultBandwidthLimit.Upload, "limit-upload", util.DefaultBandwidthLimit.Upload, "Limits uploads of restic to this rate in KiB/s, unless limited in Restic or Recovery.")
+	fs.Int32Var(&util.DefaultBandwidthLimit.Download, "limit-download", util.DefaultBandwidthLimit.Download, "Limits downloads of restic to this rate in KiB/s, unless limited in Restic or Recovery.")
+}
+
+// addConcurrencyLimitFlags adds the flag limiting concurrent backups. The operator passes it to sidecars.
+func addConcurrencyLimitFlags(fs *pflag.FlagSet) {
+	fs.IntVar(&util.MaxConcurrentBackups, "max-concurrent-backups", util.MaxConcurrentBackups, "Maximum number of sidecars taking backup at the same time in a namespace. Unlimited if zero.")
+}
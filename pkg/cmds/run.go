@@ -1,16 +1,18 @@
 package cmds
 
 import (
-	"fmt"
 	"net/http"
+	_ "net/http/pprof"
 	"time"
 
 	"github.com/appscode/go/log"
 	stringz "github.com/appscode/go/strings"
 	v "github.com/appscode/go/version"
+	"github.com/appscode/kutil/meta"
 	"github.com/appscode/pat"
-	api "github.com/appscode/stash/apis/stash"
+	"github.com/appscode/stash/apis/repositories"
 	cs "github.com/appscode/stash/client/typed/stash/v1alpha1"
+	"github.com/appscode/stash/pkg/apiserver"
 	"github.com/appscode/stash/pkg/controller"
 	"github.com/appscode/stash/pkg/docker"
 	"github.com/appscode/stash/pkg/migrator"
@@ -33,6 +35,13 @@ func NewCmdRun() *cobra.Command {
 			MaxNumRequeues:  5,
 		}
 		scratchDir = "/tmp"
+
+		apiAddress  = ":8443"
+		tlsCertFile string
+		tlsKeyFile  string
+
+		enableWebhooks = true
+		serviceName    = "stash-operator"
 	)
 
 	cmd := &cobra.Command{
@@ -83,18 +92,17 @@ func NewCmdRun() *cobra.Command {
 			defer close(stop)
 			go ctrl.Run(1, stop)
 
+			// Serve snapshots API registered via APIService. Failure to start it must not stop the operator.
+			go func() {
+				srv := apiserver.New(kubeClient, stashClient, ctrl, scratchDir, enableWebhooks, serviceName, meta.Namespace())
+				if err := srv.ListenAndServeTLS(apiAddress, tlsCertFile, tlsKeyFile); err != nil {
+					log.Errorf("Failed to serve %s API, reason: %s", repositories.GroupName, err)
+				}
+			}()
+
 			m := pat.New()
 			m.Get("/metrics", promhttp.Handler())
 
-			pattern := fmt.Sprintf("/%s/v1beta1/namespaces/%s/restics/%s/metrics", api.GroupName, PathParamNamespace, PathParamName)
-			log.Infof("URL pattern: %s", pattern)
-			exporter := &PrometheusExporter{
-				kubeClient:  kubeClient,
-				stashClient: stashClient,
-				scratchDir:  scratchDir,
-			}
-			m.Get(pattern, exporter)
-
 			http.Handle("/", m)
 			log.Infoln("Listening on", address)
 			log.Fatal(http.ListenAndServe(address, nil))
@@ -105,7 +113,14 @@ func NewCmdRun() *cobra.Command {
 	cmd.Flags().StringVar(&address, "address", address, "Address to listen on for web interface and telemetry.")
 	cmd.Flags().BoolVar(&opts.EnableRBAC, "rbac", opts.EnableRBAC, "Enable RBAC for operator")
 	cmd.Flags().StringVar(&scratchDir, "scratch-dir", scratchDir, "Directory used to store temporary files. Use an `emptyDir` in Kubernetes.")
+	cmd.Flags().StringVar(&apiAddress, "api-address", apiAddress, "Address to listen on for snapshots API server.")
+	cmd.Flags().StringVar(&tlsCertFile, "tls-cert-file", tlsCertFile, "File containing the x509 certificate for snapshots API server. If empty, a self-signed certificate is generated.")
+	cmd.Flags().StringVar(&tlsKeyFile, "tls-private-key-file", tlsKeyFile, "File containing the x509 private key matching --tls-cert-file.")
+	cmd.Flags().StringVar(&serviceName, "service-name", serviceName, "Name of the service of the operator in its namespace. Used as host of the self-signed certificate and by the admission webhooks.")
+	cmd.Flags().BoolVar(&enableWebhooks, "enable-admission-webhooks", enableWebhooks, "If true, registers the operator as admission webhook to validate Restic and Recovery and to inject sidecar into workloads. Requires Kubernetes 1.9+.")
 	cmd.Flags().DurationVar(&opts.ResyncPeriod, "resync-period", opts.ResyncPeriod, "If non-zero, will re-list this often. Otherwise, re-list will be delayed aslong as possible (until the upstream source closes the watch or times out.")
+	addBandwidthLimitFlags(cmd.Flags())
+	addConcurrencyLimitFlags(cmd.Flags())
 
 	return cmd
 }
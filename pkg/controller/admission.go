@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+
+	api "github.com/appscode/stash/apis/stash/v1alpha1"
+	"github.com/appscode/stash/pkg/admission"
+	"github.com/appscode/stash/pkg/util"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// workloadObject contains the fields shared by all supported workload kinds and API versions.
+type workloadObject struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              struct {
+		Replicas *int32               `json:"replicas,omitempty"`
+		Template core.PodTemplateSpec `json:"template"`
+	} `json:"spec"`
+}
+
+var _ admission.WorkloadMutator = &StashController{}
+
+// MutateWorkload injects or removes stash sidecar when a workload is created or updated, so that
+// workload pods come up with the sidecar on the first rollout. It makes the same changes as
+// Ensure*Sidecar and Ensure*SidecarDeleted do for existing workloads. RoleBinding for sidecar
+// is created later by the workload controller, since workload has no UID at admission time.
+func (c *StashController) MutateWorkload(req *admission.AdmissionRequest) ([]admission.PatchOperation, error) {
+	kind := req.Kind.Kind
+	switch kind {
+	case api.KindDeployment, api.KindStatefulSet, api.KindDaemonSet, api.KindReplicaSet, api.KindReplicationController:
+	default:
+		return nil, nil
+	}
+
+	obj := &workloadObject{}
+	if err := json.Unmarshal(req.Object.Raw, obj); err != nil {
+		return nil, err
+	}
+	if obj.Namespace == "" {
+		obj.Namespace = req.Namespace
+	}
+	if obj.Name == "" {
+		// generated name is not known yet, workload controller will add sidecar later.
+		return nil, nil
+	}
+	if kind == api.KindReplicaSet && metav1.GetControllerOf(obj) != nil {
+		// ReplicaSets created by Deployments inherit sidecar from Deployment's pod template.
+		return nil, nil
+	}
+
+	oldRestic, err := util.GetAppliedRestic(obj.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	newRestic, err := util.FindRestic(c.rstLister, obj.ObjectMeta)
+	if err != nil {
+		return nil, err
+	}
+	if util.ResticEqual(oldRestic, newRestic) {
+		return nil, nil
+	}
+
+	spec := obj.Spec.Template.Spec
+	hasInitContainers := len(spec.InitContainers) > 0
+	annotations := make(map[string]string)
+	for k, v := range obj.Annotations {
+		annotations[k] = v
+	}
+	if newRestic != nil {
+		if newRestic.Spec.Type == api.BackupOffline && obj.Spec.Replicas != nil && *obj.Spec.Replicas > 1 {
+			return nil, fmt.Errorf("cannot perform offline backup for %s with replicas > 1", kind)
+		}
+		workload := api.LocalTypedReference{
+			Kind: kind,
+			Name: obj.Name,
+		}
+		spec = util.InjectSidecar(spec, oldRestic, newRestic, workload, c.options.SidecarImageTag, c.options.EnableRBAC)
+		annotations = util.SetAppliedRestic(annotations, newRestic, c.options.SidecarImageTag)
+	} else {
+		spec = util.RemoveSidecar(spec, oldRestic)
+		util.RemoveAppliedRestic(annotations)
+	}
+
+	// "add" replaces the value of an existing member, so it works whether the field is set or not.
+	patch := []admission.PatchOperation{
+		{Op: "add", Path: "/metadata/annotations", Value: annotations},
+		{Op: "add", Path: "/spec/template/spec/containers", Value: spec.Containers},
+		{Op: "add", Path: "/spec/template/spec/volumes", Value: spec.Volumes},
+	}
+	if hasInitContainers || len(spec.InitContainers) > 0 {
+		patch = append(patch, admission.PatchOperation{Op: "add", Path: "/spec/template/spec/initContainers", Value: spec.InitContainers})
+	}
+	return patch, nil
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"strings"
+
+	"github.com/appscode/go/log"
+	api "github.com/appscode/stash/apis/stash/v1alpha1"
+	stash_util "github.com/appscode/stash/client/typed/stash/v1alpha1/util"
+	"github.com/appscode/stash/pkg/eventer"
+	"github.com/appscode/stash/pkg/util"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rt "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+func (c *StashController) initBackupSessionWatcher() {
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (rt.Object, error) {
+			return c.stashClient.BackupSessions(core.NamespaceAll).List(options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return c.stashClient.BackupSessions(core.NamespaceAll).Watch(options)
+		},
+	}
+
+	// Sessions are only processed once, when they are created.
+	c.bsIndexer, c.bsInformer = cache.NewIndexerInformer(lw, &api.BackupSession{}, c.options.ResyncPeriod, cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if s, ok := obj.(*api.BackupSession); ok && s.Status.ExpectedHosts == nil &&
+				s.Status.Phase != api.BackupSessionSucceeded && s.Status.Phase != api.BackupSessionFailed {
+				if err := c.recordExpectedHosts(s); err != nil {
+					log.Errorf("Failed to record expected hosts of BackupSession %s/%s, reason: %s", s.Namespace, s.Name, err)
+				}
+			}
+		},
+	}, cache.Indexers{})
+}
+
+// recordExpectedHosts records the hosts whose sidecars are running in status of session, so that
+// the session is not finished before all of them took the backup.
+func (c *StashController) recordExpectedHosts(session *api.BackupSession) error {
+	restic, err := c.rstLister.Restics(session.Namespace).Get(session.Spec.Restic)
+	if err != nil {
+		return err
+	}
+	hosts, err := c.sidecarHosts(restic)
+	if err != nil {
+		return err
+	}
+	if len(hosts) == 0 {
+		c.recorder.Eventf(
+			session.ObjectReference(),
+			core.EventTypeWarning,
+			eventer.EventReasonFailedToBackup,
+			"No running pod has the sidecar of Restic %s",
+			restic.Name,
+		)
+	}
+	_, err = stash_util.TryUpdateBackupSession(c.stashClient, session.ObjectMeta, func(in *api.BackupSession) *api.BackupSession {
+		in.Status.ExpectedHosts = hosts
+		in.Status.Phase = in.Status.CalculatePhase()
+		if len(hosts) == 0 && len(in.Status.Hosts) == 0 {
+			in.Status.Phase = api.BackupSessionFailed
+		}
+		return in
+	})
+	return err
+}
+
+// sidecarHosts returns the hostnames used in snapshots by the running sidecars of restic.
+func (c *StashController) sidecarHosts(restic *api.Restic) ([]string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&restic.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := c.k8sClient.CoreV1().Pods(restic.Namespace).List(metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := sets.NewString()
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != core.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		for _, container := range pod.Spec.Containers {
+			if container.Name != util.StashContainer {
+				continue
+			}
+			var workload api.LocalTypedReference
+			resticName := ""
+			for _, arg := range container.Args {
+				switch {
+				case strings.HasPrefix(arg, "--restic-name="):
+					resticName = strings.TrimPrefix(arg, "--restic-name=")
+				case strings.HasPrefix(arg, "--workload-kind="):
+					workload.Kind = strings.TrimPrefix(arg, "--workload-kind=")
+				case strings.HasPrefix(arg, "--workload-name="):
+					workload.Name = strings.TrimPrefix(arg, "--workload-name=")
+				}
+			}
+			if resticName != restic.Name {
+				continue
+			}
+			hostname, _, err := workload.HostnamePrefix(pod.Name, pod.Spec.NodeName)
+			if err != nil {
+				log.Warningf("Failed to get hostname of pod %s/%s, reason: %s", pod.Namespace, pod.Name, err)
+				continue
+			}
+			hosts.Insert(hostname)
+		}
+	}
+	return hosts.List(), nil
+}
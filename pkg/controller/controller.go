@@ -2,6 +2,7 @@ package controller
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	apiext_util "github.com/appscode/kutil/apiextensions/v1beta1"
@@ -10,6 +11,7 @@ import (
 	stash_listers "github.com/appscode/stash/listers/stash/v1alpha1"
 	"github.com/appscode/stash/pkg/eventer"
 	"github.com/golang/glog"
+	"gopkg.in/robfig/cron.v2"
 	crd_api "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	crd_cs "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/util/runtime"
@@ -41,6 +43,20 @@ type StashController struct {
 	rstInformer cache.Controller
 	rstLister   stash_listers.ResticLister
 
+	// BackupTemplate
+	btIndexer  cache.Indexer
+	btInformer cache.Controller
+	btLister   stash_listers.BackupTemplateLister
+
+	// ClusterRetentionPolicy
+	crpIndexer  cache.Indexer
+	crpInformer cache.Controller
+	crpLister   stash_listers.ClusterRetentionPolicyLister
+
+	// BackupSession
+	bsIndexer  cache.Indexer
+	bsInformer cache.Controller
+
 	// Recovery
 	recQueue    workqueue.RateLimitingInterface
 	recIndexer  cache.Indexer
@@ -82,6 +98,11 @@ type StashController struct {
 	jobIndexer  cache.Indexer
 	jobInformer cache.Controller
 	jobLister   batch_listers.JobLister
+
+	// Maintenance schedules of Restics, by Restic key
+	cron               *cron.Cron
+	maintenanceLock    sync.Mutex
+	maintenanceEntries map[string][]cron.EntryID
 }
 
 func New(kubeClient kubernetes.Interface, crdClient crd_cs.ApiextensionsV1beta1Interface, stashClient cs.StashV1alpha1Interface, options Options) *StashController {
@@ -91,6 +112,9 @@ func New(kubeClient kubernetes.Interface, crdClient crd_cs.ApiextensionsV1beta1I
 		crdClient:   crdClient,
 		options:     options,
 		recorder:    eventer.NewEventRecorder(kubeClient, "stash-controller"),
+
+		cron:               cron.New(),
+		maintenanceEntries: map[string][]cron.EntryID{},
 	}
 }
 
@@ -105,6 +129,9 @@ func (c *StashController) Setup() error {
 	}
 	c.initNamespaceWatcher()
 	c.initResticWatcher()
+	c.initBackupTemplateWatcher()
+	c.initClusterRetentionPolicyWatcher()
+	c.initBackupSessionWatcher()
 	c.initRecoveryWatcher()
 	c.initDeploymentWatcher()
 	c.initDaemonSetWatcher()
@@ -119,6 +146,10 @@ func (c *StashController) ensureCustomResourceDefinitions() error {
 	crds := []*crd_api.CustomResourceDefinition{
 		api.Restic{}.CustomResourceDefinition(),
 		api.Recovery{}.CustomResourceDefinition(),
+		api.Repository{}.CustomResourceDefinition(),
+		api.BackupSession{}.CustomResourceDefinition(),
+		api.BackupTemplate{}.CustomResourceDefinition(),
+		api.ClusterRetentionPolicy{}.CustomResourceDefinition(),
 	}
 	return apiext_util.RegisterCRDs(c.crdClient, crds)
 }
@@ -139,6 +170,9 @@ func (c *StashController) Run(threadiness int, stopCh chan struct{}) {
 
 	go c.nsInformer.Run(stopCh)
 	go c.rstInformer.Run(stopCh)
+	go c.btInformer.Run(stopCh)
+	go c.crpInformer.Run(stopCh)
+	go c.bsInformer.Run(stopCh)
 	go c.recInformer.Run(stopCh)
 	go c.dpInformer.Run(stopCh)
 	go c.dsInformer.Run(stopCh)
@@ -147,6 +181,9 @@ func (c *StashController) Run(threadiness int, stopCh chan struct{}) {
 	go c.rsInformer.Run(stopCh)
 	go c.jobInformer.Run(stopCh)
 
+	c.cron.Start()
+	defer c.cron.Stop()
+
 	// Wait for all involved caches to be synced, before processing items from the queue is started
 	if !cache.WaitForCacheSync(stopCh, c.nsInformer.HasSynced) {
 		runtime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
@@ -156,6 +193,14 @@ func (c *StashController) Run(threadiness int, stopCh chan struct{}) {
 		runtime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
 		return
 	}
+	if !cache.WaitForCacheSync(stopCh, c.btInformer.HasSynced) {
+		runtime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
+		return
+	}
+	if !cache.WaitForCacheSync(stopCh, c.crpInformer.HasSynced) {
+		runtime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
+		return
+	}
 	if !cache.WaitForCacheSync(stopCh, c.recInformer.HasSynced) {
 		runtime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
 		return
@@ -5,9 +5,7 @@ import (
 
 	"github.com/appscode/go/log"
 	stringz "github.com/appscode/go/strings"
-	core_util "github.com/appscode/kutil/core/v1"
 	ext_util "github.com/appscode/kutil/extensions/v1beta1"
-	"github.com/appscode/kutil/meta"
 	api "github.com/appscode/stash/apis/stash/v1alpha1"
 	"github.com/appscode/stash/pkg/util"
 	"github.com/golang/glog"
@@ -144,7 +142,7 @@ func (c *StashController) runDaemonSetInjector(key string) error {
 			return err
 		}
 		if util.ResticEqual(oldRestic, newRestic) {
-			return nil
+			return c.ensureInjectedSidecarRoleBinding(ds, ds.Spec.Template.Spec, newRestic)
 		}
 		if newRestic != nil {
 			return c.EnsureDaemonSetSidecar(ds, oldRestic, newRestic)
@@ -208,30 +206,8 @@ func (c *StashController) EnsureDaemonSetSidecar(resource *extensions.DaemonSet,
 			Kind: api.KindDaemonSet,
 			Name: obj.Name,
 		}
-		if new.Spec.Type == api.BackupOffline {
-			obj.Spec.Template.Spec.InitContainers = core_util.UpsertContainer(obj.Spec.Template.Spec.InitContainers, util.NewInitContainer(new, c.options.SidecarImageTag, workload, c.options.EnableRBAC))
-		} else {
-			obj.Spec.Template.Spec.Containers = core_util.UpsertContainer(obj.Spec.Template.Spec.Containers, util.NewSidecarContainer(new, c.options.SidecarImageTag, workload))
-		}
-		obj.Spec.Template.Spec.Volumes = util.UpsertScratchVolume(obj.Spec.Template.Spec.Volumes)
-		obj.Spec.Template.Spec.Volumes = util.UpsertDownwardVolume(obj.Spec.Template.Spec.Volumes)
-		obj.Spec.Template.Spec.Volumes = util.MergeLocalVolume(obj.Spec.Template.Spec.Volumes, old, new)
-
-		if obj.Annotations == nil {
-			obj.Annotations = make(map[string]string)
-		}
-
-		r := &api.Restic{
-			TypeMeta: metav1.TypeMeta{
-				APIVersion: api.SchemeGroupVersion.String(),
-				Kind:       api.ResourceKindRestic,
-			},
-			ObjectMeta: new.ObjectMeta,
-			Spec:       new.Spec,
-		}
-		data, _ := meta.MarshalToJson(r, api.SchemeGroupVersion)
-		obj.Annotations[api.LastAppliedConfiguration] = string(data)
-		obj.Annotations[api.VersionTag] = c.options.SidecarImageTag
+		obj.Spec.Template.Spec = util.InjectSidecar(obj.Spec.Template.Spec, old, new, workload, c.options.SidecarImageTag, c.options.EnableRBAC)
+		obj.Annotations = util.SetAppliedRestic(obj.Annotations, new, c.options.SidecarImageTag)
 		return obj
 	})
 	if err != nil {
@@ -255,20 +231,8 @@ func (c *StashController) EnsureDaemonSetSidecarDeleted(resource *extensions.Dae
 	}
 
 	resource, _, err = ext_util.PatchDaemonSet(c.k8sClient, resource, func(obj *extensions.DaemonSet) *extensions.DaemonSet {
-		if restic.Spec.Type == api.BackupOffline {
-			obj.Spec.Template.Spec.InitContainers = core_util.EnsureContainerDeleted(obj.Spec.Template.Spec.InitContainers, util.StashContainer)
-		} else {
-			obj.Spec.Template.Spec.Containers = core_util.EnsureContainerDeleted(obj.Spec.Template.Spec.Containers, util.StashContainer)
-		}
-		obj.Spec.Template.Spec.Volumes = util.EnsureVolumeDeleted(obj.Spec.Template.Spec.Volumes, util.ScratchDirVolumeName)
-		obj.Spec.Template.Spec.Volumes = util.EnsureVolumeDeleted(obj.Spec.Template.Spec.Volumes, util.PodinfoVolumeName)
-		if restic.Spec.Backend.Local != nil {
-			obj.Spec.Template.Spec.Volumes = util.EnsureVolumeDeleted(obj.Spec.Template.Spec.Volumes, util.LocalVolumeName)
-		}
-		if obj.Annotations != nil {
-			delete(obj.Annotations, api.LastAppliedConfiguration)
-			delete(obj.Annotations, api.VersionTag)
-		}
+		obj.Spec.Template.Spec = util.RemoveSidecar(obj.Spec.Template.Spec, restic)
+		util.RemoveAppliedRestic(obj.Annotations)
 		return obj
 	})
 	if err != nil {
@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/appscode/go/log"
+	api "github.com/appscode/stash/apis/stash/v1alpha1"
+	"github.com/appscode/stash/pkg/eventer"
+	"github.com/appscode/stash/pkg/util"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/reference"
+)
+
+// maintenanceSchedules returns the schedule of each maintenance task enabled in spec.
+func maintenanceSchedules(spec *api.MaintenanceSpec) map[api.MaintenanceTask]string {
+	schedules := map[api.MaintenanceTask]string{
+		api.MaintenanceTaskCheck: api.DefaultCheckSchedule,
+	}
+	if spec == nil {
+		return schedules
+	}
+	if spec.Check != nil && spec.Check.Schedule != "" {
+		schedules[api.MaintenanceTaskCheck] = spec.Check.Schedule
+	}
+	if spec.Prune != nil && spec.Prune.Schedule != "" {
+		schedules[api.MaintenanceTaskPrune] = spec.Prune.Schedule
+	}
+	if spec.Unlock != nil && spec.Unlock.Schedule != "" {
+		schedules[api.MaintenanceTaskUnlock] = spec.Unlock.Schedule
+	}
+	if spec.RebuildIndex != nil && spec.RebuildIndex.Schedule != "" {
+		schedules[api.MaintenanceTaskRebuildIndex] = spec.RebuildIndex.Schedule
+	}
+	return schedules
+}
+
+// configureMaintenance replaces the maintenance schedules of a Restic.
+func (c *StashController) configureMaintenance(restic *api.Restic) error {
+	key := restic.Namespace + "/" + restic.Name
+	c.maintenanceLock.Lock()
+	defer c.maintenanceLock.Unlock()
+
+	for _, id := range c.maintenanceEntries[key] {
+		c.cron.Remove(id)
+	}
+	delete(c.maintenanceEntries, key)
+
+	namespace, name := restic.Namespace, restic.Name
+	for task, schedule := range maintenanceSchedules(restic.Spec.Maintenance) {
+		task := task
+		id, err := c.cron.AddFunc(schedule, func() {
+			c.runMaintenance(namespace, name, task)
+		})
+		if err != nil {
+			return fmt.Errorf("invalid schedule %s for %s, reason: %s", schedule, task, err)
+		}
+		c.maintenanceEntries[key] = append(c.maintenanceEntries[key], id)
+	}
+	return nil
+}
+
+func (c *StashController) deleteMaintenance(key string) {
+	c.maintenanceLock.Lock()
+	defer c.maintenanceLock.Unlock()
+
+	for _, id := range c.maintenanceEntries[key] {
+		c.cron.Remove(id)
+	}
+	delete(c.maintenanceEntries, key)
+}
+
+// runMaintenance creates a Job running task for every Repository of a Restic, so that each
+// repository is maintained once, regardless of the number of sidecars writing into it.
+func (c *StashController) runMaintenance(namespace, name string, task api.MaintenanceTask) {
+	restic, err := c.rstLister.Restics(namespace).Get(name)
+	if err != nil {
+		if !kerr.IsNotFound(err) {
+			log.Errorf("Failed to get Restic %s/%s, reason: %s", namespace, name, err)
+		}
+		return
+	}
+	restic = restic.Resolved()
+	repositories, err := c.stashClient.Repositories(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		log.Errorf("Failed to list repositories of Restic %s/%s, reason: %s", namespace, name, err)
+		return
+	}
+	for i := range repositories.Items {
+		repository := &repositories.Items[i]
+		if repository.Spec.Restic != restic.Name {
+			continue
+		}
+		if err := c.createMaintenanceJob(restic, repository, task); err != nil {
+			log.Errorln(err)
+			c.recorder.Eventf(
+				repository.ObjectReference(),
+				core.EventTypeWarning,
+				eventer.EventReasonFailedMaintenance,
+				"Failed to create %s job, reason: %s",
+				task,
+				err,
+			)
+		}
+	}
+}
+
+func (c *StashController) createMaintenanceJob(restic *api.Restic, repository *api.Repository, task api.MaintenanceTask) error {
+	name := util.MaintenanceJobName(task, repository.Name)
+	if job, err := c.jobLister.Jobs(repository.Namespace).Get(name); err == nil {
+		if job.Status.Active > 0 {
+			log.Warningf("Skipping %s of repository %s/%s, previous job is still running", task, repository.Namespace, repository.Name)
+			return nil
+		}
+		// succeeded jobs are deleted by the job watcher, failed ones are kept until the next run
+		deletePolicy := metav1.DeletePropagationBackground
+		err = c.k8sClient.BatchV1().Jobs(job.Namespace).Delete(job.Name, &metav1.DeleteOptions{
+			PropagationPolicy: &deletePolicy,
+		})
+		if err != nil && !kerr.IsNotFound(err) {
+			return fmt.Errorf("failed to delete previous job %s, reason: %s", job.Name, err)
+		}
+	}
+
+	var readDataSubset string
+	if task == api.MaintenanceTaskCheck && restic.Spec.Maintenance != nil && restic.Spec.Maintenance.Check != nil {
+		readDataSubset = nextReadDataSubset(repository.Status, restic.Spec.Maintenance.Check.ReadDataSubsets)
+	}
+
+	job := util.NewMaintenanceJob(restic, repository, task, readDataSubset, c.options.SidecarImageTag)
+	if c.options.EnableRBAC {
+		job.Spec.Template.Spec.ServiceAccountName = job.Name
+	}
+	job, err := c.k8sClient.BatchV1().Jobs(repository.Namespace).Create(job)
+	if err != nil {
+		return err
+	}
+
+	if c.options.EnableRBAC {
+		ref, err := reference.GetReference(scheme.Scheme, job)
+		if err != nil {
+			return err
+		}
+		if err := c.ensureJobRBAC(ref); err != nil {
+			return fmt.Errorf("error ensuring rbac for maintenance job %s, reason: %s\n", job.Name, err)
+		}
+	}
+
+	log.Infoln("Maintenance job created:", job.Name)
+	c.recorder.Eventf(repository.ObjectReference(), core.EventTypeNormal, eventer.EventReasonMaintenanceJobCreated, "Maintenance job created: %s", job.Name)
+	return nil
+}
+
+// nextReadDataSubset returns the subset of data to be read by the next check, eg. 3/5 if
+// the last check read 2/5. If the last check failed, the same subset is read again. Checks
+// start from the first subset if the number of subsets changed.
+func nextReadDataSubset(status api.RepositoryStatus, subsets int32) string {
+	if subsets <= 0 {
+		return ""
+	}
+	next := int32(1)
+	for _, s := range status.Maintenance {
+		if s.Task != api.MaintenanceTaskCheck {
+			continue
+		}
+		parts := strings.SplitN(s.ReadDataSubset, "/", 2)
+		if len(parts) != 2 || parts[1] != strconv.Itoa(int(subsets)) {
+			break
+		}
+		if last, err := strconv.Atoi(parts[0]); err == nil && last >= 1 && last <= int(subsets) {
+			if s.Error != "" {
+				next = int32(last)
+			} else {
+				next = int32(last)%subsets + 1
+			}
+		}
+		break
+	}
+	return fmt.Sprintf("%d/%d", next, subsets)
+}
@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"testing"
+
+	api "github.com/appscode/stash/apis/stash/v1alpha1"
+)
+
+func TestNextReadDataSubset(t *testing.T) {
+	status := func(maintenance ...api.MaintenanceStatus) api.RepositoryStatus {
+		return api.RepositoryStatus{Maintenance: maintenance}
+	}
+	check := func(subset, err string) api.MaintenanceStatus {
+		return api.MaintenanceStatus{Task: api.MaintenanceTaskCheck, ReadDataSubset: subset, Error: err}
+	}
+	prune := api.MaintenanceStatus{Task: api.MaintenanceTaskPrune}
+
+	cases := []struct {
+		name     string
+		status   api.RepositoryStatus
+		subsets  int32
+		expected string
+	}{
+		{"disabled", status(check("2/5", "")), 0, ""},
+		{"first check", status(), 5, "1/5"},
+		{"only other tasks", status(prune), 5, "1/5"},
+		{"next subset", status(prune, check("2/5", "")), 5, "3/5"},
+		{"last subset", status(check("5/5", "")), 5, "1/5"},
+		{"single subset", status(check("1/1", "")), 1, "1/1"},
+		{"retry after failure", status(check("2/5", "exit status 1")), 5, "2/5"},
+		{"subsets changed", status(check("2/5", "")), 3, "1/3"},
+		{"check without subset", status(check("", "")), 5, "1/5"},
+		{"out of range", status(check("7/5", "")), 5, "1/5"},
+		{"invalid", status(check("x/5", "")), 5, "1/5"},
+	}
+	for _, c := range cases {
+		if actual := nextReadDataSubset(c.status, c.subsets); actual != c.expected {
+			t.Errorf("%s: expected %q, got %q", c.name, c.expected, actual)
+		}
+	}
+}
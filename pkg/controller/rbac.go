@@ -2,6 +2,7 @@ package controller
 
 import (
 	"github.com/appscode/go/log"
+	stringz "github.com/appscode/go/strings"
 	core_util "github.com/appscode/kutil/core/v1"
 	rbac_util "github.com/appscode/kutil/rbac/v1beta1"
 	api "github.com/appscode/stash/apis/stash/v1alpha1"
@@ -12,6 +13,9 @@ import (
 	extensions "k8s.io/api/extensions/v1beta1"
 	rbac "k8s.io/api/rbac/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/reference"
 )
 
 const (
@@ -53,6 +57,19 @@ func (c *StashController) ensureSidecarRoleBinding(resource *core.ObjectReferenc
 	return err
 }
 
+// ensureInjectedSidecarRoleBinding creates RoleBinding for a sidecar injected by the mutating webhook.
+// Workloads have no UID at admission time, so the RoleBinding can't be owned by them until then.
+func (c *StashController) ensureInjectedSidecarRoleBinding(resource runtime.Object, spec core.PodSpec, restic *api.Restic) error {
+	if restic == nil || !c.options.EnableRBAC {
+		return nil
+	}
+	ref, err := reference.GetReference(scheme.Scheme, resource)
+	if err != nil {
+		return err
+	}
+	return c.ensureSidecarRoleBinding(ref, stringz.Val(spec.ServiceAccountName, "default"))
+}
+
 func (c *StashController) ensureSidecarRoleBindingDeleted(resource metav1.ObjectMeta) error {
 	log.Infof("Deleting RoleBinding %s/%s", resource.Namespace, c.getSidecarRoleBindingName(resource.Name))
 	return c.k8sClient.RbacV1beta1().
@@ -99,6 +116,16 @@ func (c *StashController) ensureSidecarClusterRole() error {
 				Resources: []string{"events"},
 				Verbs:     []string{"create"},
 			},
+			{
+				APIGroups: []string{core.GroupName},
+				Resources: []string{"pods"},
+				Verbs:     []string{"get"},
+			},
+			{
+				APIGroups: []string{core.GroupName},
+				Resources: []string{"pods/exec"},
+				Verbs:     []string{"create"},
+			},
 			{
 				APIGroups: []string{batch.GroupName},
 				Resources: []string{"jobs"},
@@ -194,7 +221,7 @@ func (c *StashController) ensureKubectlRBAC(resource *core.ObjectReference) erro
 
 // use sidecar-cluster-role, service-account and role-binding name same as job name
 // set job as owner of service-account and role-binding
-func (c *StashController) ensureRecoveryRBAC(resource *core.ObjectReference) error {
+func (c *StashController) ensureJobRBAC(resource *core.ObjectReference) error {
 	// ensure service account
 	meta := metav1.ObjectMeta{
 		Name:      resource.Name,
@@ -189,7 +189,7 @@ func (c *StashController) runRecoveryJob(rec *api.Recovery) error {
 		if err != nil {
 			return err
 		}
-		if err := c.ensureRecoveryRBAC(ref); err != nil {
+		if err := c.ensureJobRBAC(ref); err != nil {
 			return fmt.Errorf("error ensuring rbac for recovery job %s, reason: %s\n", job.Name, err)
 		}
 	}
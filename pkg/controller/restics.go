@@ -7,7 +7,9 @@ import (
 	batch_util "github.com/appscode/kutil/batch/v1beta1"
 	core_util "github.com/appscode/kutil/core/v1"
 	ext_util "github.com/appscode/kutil/extensions/v1beta1"
+	"github.com/appscode/kutil/meta"
 	api "github.com/appscode/stash/apis/stash/v1alpha1"
+	stash_util "github.com/appscode/stash/client/typed/stash/v1alpha1/util"
 	stash_listers "github.com/appscode/stash/listers/stash/v1alpha1"
 	"github.com/appscode/stash/pkg/docker"
 	"github.com/appscode/stash/pkg/eventer"
@@ -46,7 +48,11 @@ func (c *StashController) initResticWatcher() {
 	c.rstIndexer, c.rstInformer = cache.NewIndexerInformer(lw, &api.Restic{}, c.options.ResyncPeriod, cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			if r, ok := obj.(*api.Restic); ok {
-				if err := r.IsValid(); err != nil {
+				resolved, err := c.resolveRestic(r)
+				if err == nil {
+					err = resolved.IsValid()
+				}
+				if err != nil {
 					c.recorder.Eventf(
 						r.ObjectReference(),
 						core.EventTypeWarning,
@@ -74,7 +80,11 @@ func (c *StashController) initResticWatcher() {
 				log.Errorln("Invalid Restic object")
 				return
 			}
-			if err := newObj.IsValid(); err != nil {
+			resolved, err := c.resolveRestic(newObj)
+			if err == nil {
+				err = resolved.IsValid()
+			}
+			if err != nil {
 				c.recorder.Eventf(
 					newObj.ObjectReference(),
 					core.EventTypeWarning,
@@ -83,7 +93,9 @@ func (c *StashController) initResticWatcher() {
 					err,
 				)
 				return
-			} else if !util.ResticEqual(oldObj, newObj) {
+			} else if !util.ResticEqual(oldObj.Resolved(), newObj.Resolved()) {
+				// also re-synced when the operator updates the template in its status,
+				// so that workloads are processed with the updated Restic in the cache
 				key, err := cache.MetaNamespaceKeyFunc(new)
 				if err == nil {
 					c.rstQueue.Add(key)
@@ -165,10 +177,45 @@ func (c *StashController) runResticInjector(key string) error {
 			return err
 		}
 		c.EnsureSidecarDeleted(namespace, name)
+		c.deleteMaintenance(key)
 	} else {
 		restic := obj.(*api.Restic)
 		glog.Infof("Sync/Add/Update for Restic %s\n", restic.GetName())
 
+		// store the BackupTemplate and ClusterRetentionPolicies in the status for the sidecars
+		template, policies, err := c.resticTemplates(restic)
+		if err != nil {
+			c.recorder.Eventf(
+				restic.ObjectReference(),
+				core.EventTypeWarning,
+				eventer.EventReasonFailedToResolveTemplate,
+				"Failed to resolve templates, reason: %v",
+				err,
+			)
+			if restic.Status.TemplateError != err.Error() {
+				_, _, e := stash_util.PatchRestic(c.stashClient, restic, func(in *api.Restic) *api.Restic {
+					in.Status.TemplateError = err.Error()
+					return in
+				})
+				if e != nil {
+					log.Errorf("Failed to update status of Restic %s, reason: %s", key, e)
+				}
+			}
+			return err
+		}
+		if !meta.Equal(restic.Status.Template, template) || !meta.Equal(restic.Status.ClusterRetentionPolicies, policies) || restic.Status.TemplateError != "" {
+			restic, _, err = stash_util.PatchRestic(c.stashClient, restic, func(in *api.Restic) *api.Restic {
+				in.Status.Template = template
+				in.Status.ClusterRetentionPolicies = policies
+				in.Status.TemplateError = ""
+				return in
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update templates in status of Restic %s, reason: %s", key, err)
+			}
+		}
+		restic = restic.Resolved()
+
 		if restic.Spec.Type == api.BackupOffline {
 			meta := metav1.ObjectMeta{
 				Name:      util.KubectlCronPrefix + restic.Name,
@@ -241,6 +288,10 @@ func (c *StashController) runResticInjector(key string) error {
 			}
 		}
 
+		if err = c.configureMaintenance(restic); err != nil {
+			return err
+		}
+
 		// for online backup
 		c.EnsureSidecar(restic)
 		c.EnsureSidecarDeleted(restic.Namespace, restic.Name)
@@ -280,16 +331,16 @@ func (c *StashController) EnsureSidecar(restic *api.Restic) {
 			}
 		}
 	}
-	//{
-	//	if resources, err := c.ssLister.StatefulSets(restic.Namespace).List(sel); err == nil {
-	//		for _, resource := range resources {
-	//			key, err := cache.MetaNamespaceKeyFunc(resource)
-	//			if err == nil {
-	//				c.ssQueue.Add(key)
-	//			}
-	//		}
-	//	}
-	//}
+	{
+		if resources, err := c.ssLister.StatefulSets(restic.Namespace).List(sel); err == nil {
+			for _, resource := range resources {
+				key, err := cache.MetaNamespaceKeyFunc(resource)
+				if err == nil {
+					c.ssQueue.Add(key)
+				}
+			}
+		}
+	}
 	{
 		if resources, err := c.rcLister.ReplicationControllers(restic.Namespace).List(sel); err == nil {
 			for _, resource := range resources {
@@ -359,25 +410,27 @@ func (c *StashController) EnsureSidecarDeleted(namespace, name string) {
 			}
 		}
 	}
-	//if resources, err := c.ssLister.StatefulSets(namespace).List(labels.Everything()); err == nil {
-	//	for _, resource := range resources {
-	//		restic, err := util.GetAppliedRestic(resource.Annotations)
-	//		if err != nil {
-	//			c.recorder.Eventf(
-	//				kutil.GetObjectReference(resource, apps.SchemeGroupVersion),
-	//				core.EventTypeWarning,
-	//				eventer.EventReasonInvalidRestic,
-	//				"Reason: %s",
-	//				err.Error(),
-	//			)
-	//		} else if restic != nil && restic.Namespace == namespace && restic.Name == name {
-	//			key, err := cache.MetaNamespaceKeyFunc(resource)
-	//			if err == nil {
-	//				c.ssQueue.Add(key)
-	//			}
-	//		}
-	//	}
-	//}
+	if resources, err := c.ssLister.StatefulSets(namespace).List(labels.Everything()); err == nil {
+		for _, resource := range resources {
+			restic, err := util.GetAppliedRestic(resource.Annotations)
+			if err != nil {
+				if ref, e2 := reference.GetReference(scheme.Scheme, resource); e2 == nil {
+					c.recorder.Eventf(
+						ref,
+						core.EventTypeWarning,
+						eventer.EventReasonInvalidRestic,
+						"Reason: %s",
+						err.Error(),
+					)
+				}
+			} else if restic != nil && restic.Namespace == namespace && restic.Name == name {
+				key, err := cache.MetaNamespaceKeyFunc(resource)
+				if err == nil {
+					c.ssQueue.Add(key)
+				}
+			}
+		}
+	}
 	if resources, err := c.rcLister.ReplicationControllers(namespace).List(labels.Everything()); err == nil {
 		for _, resource := range resources {
 			restic, err := util.GetAppliedRestic(resource.Annotations)
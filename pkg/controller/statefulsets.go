@@ -2,12 +2,11 @@ package controller
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/appscode/go/log"
 	stringz "github.com/appscode/go/strings"
 	apps_util "github.com/appscode/kutil/apps/v1beta1"
-	core_util "github.com/appscode/kutil/core/v1"
-	"github.com/appscode/kutil/meta"
 	api "github.com/appscode/stash/apis/stash/v1alpha1"
 	"github.com/appscode/stash/pkg/util"
 	"github.com/golang/glog"
@@ -125,6 +124,12 @@ func (c *StashController) runStatefulSetInjector(key string) error {
 	if !exists {
 		// Below we will warm up our cache with a StatefulSet, so that we will see a delete for one d
 		glog.Warningf("StatefulSet %s does not exist anymore\n", key)
+
+		ns, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			return err
+		}
+		util.DeleteConfigmapLock(c.k8sClient, ns, api.LocalTypedReference{Kind: api.KindStatefulSet, Name: name})
 	} else {
 		ss := obj.(*apps.StatefulSet)
 		glog.Infof("Sync/Add/Update for StatefulSet %s\n", ss.GetName())
@@ -134,27 +139,32 @@ func (c *StashController) runStatefulSetInjector(key string) error {
 			return nil
 		}
 
-		if util.ToBeInitializedBySelf(ss.Initializers) {
-			// StatefulSets are supported during initializer phase
-			oldRestic, err := util.GetAppliedRestic(ss.Annotations)
-			if err != nil {
-				return err
-			}
-			newRestic, err := util.FindRestic(c.rstLister, ss.ObjectMeta)
-			if err != nil {
-				log.Errorf("Error while searching Restic for StatefulSet %s/%s.", ss.Name, ss.Namespace)
+		oldRestic, err := util.GetAppliedRestic(ss.Annotations)
+		if err != nil {
+			return err
+		}
+		newRestic, err := util.FindRestic(c.rstLister, ss.ObjectMeta)
+		if err != nil {
+			log.Errorf("Error while searching Restic for StatefulSet %s/%s.", ss.Name, ss.Namespace)
+			return err
+		}
+		if util.ResticEqual(oldRestic, newRestic) {
+			if err = c.ensureInjectedSidecarRoleBinding(ss, ss.Spec.Template.Spec, newRestic); err != nil {
 				return err
 			}
-			if util.ResticEqual(oldRestic, newRestic) {
-				return nil
-			}
-			if newRestic != nil {
-				return c.EnsureStatefulSetSidecar(ss, oldRestic, newRestic)
-			} else if oldRestic != nil {
-				return c.EnsureStatefulSetSidecarDeleted(ss, oldRestic)
+			if _, pending := ss.Annotations[api.PendingRollout]; pending {
+				return c.rolloutStatefulSet(ss)
 			}
+			return nil
+		}
+		if newRestic != nil {
+			return c.EnsureStatefulSetSidecar(ss, oldRestic, newRestic)
+		} else if oldRestic != nil {
+			return c.EnsureStatefulSetSidecarDeleted(ss, oldRestic)
+		}
 
-			// not restic workload, just remove the pending stash initializer
+		// not restic workload, just remove the pending stash initializer
+		if util.ToBeInitializedBySelf(ss.Initializers) {
 			_, _, err = apps_util.PatchStatefulSet(c.k8sClient, ss, func(obj *apps.StatefulSet) *apps.StatefulSet {
 				fmt.Println("Removing pending stash initializer for", obj.Name)
 				if len(obj.Initializers.Pending) == 1 {
@@ -209,42 +219,16 @@ func (c *StashController) EnsureStatefulSetSidecar(resource *apps.StatefulSet, o
 			Kind: api.KindStatefulSet,
 			Name: obj.Name,
 		}
-		if new.Spec.Type == api.BackupOffline {
-			obj.Spec.Template.Spec.InitContainers = core_util.UpsertContainer(obj.Spec.Template.Spec.InitContainers, util.NewInitContainer(new, c.options.SidecarImageTag, workload, c.options.EnableRBAC))
-		} else {
-			obj.Spec.Template.Spec.Containers = core_util.UpsertContainer(obj.Spec.Template.Spec.Containers, util.NewSidecarContainer(new, c.options.SidecarImageTag, workload))
-		}
-		obj.Spec.Template.Spec.Volumes = util.UpsertScratchVolume(obj.Spec.Template.Spec.Volumes)
-		obj.Spec.Template.Spec.Volumes = util.UpsertDownwardVolume(obj.Spec.Template.Spec.Volumes)
-		obj.Spec.Template.Spec.Volumes = util.MergeLocalVolume(obj.Spec.Template.Spec.Volumes, old, new)
-
-		if obj.Annotations == nil {
-			obj.Annotations = make(map[string]string)
-		}
-		r := &api.Restic{
-			TypeMeta: metav1.TypeMeta{
-				APIVersion: api.SchemeGroupVersion.String(),
-				Kind:       api.ResourceKindRestic,
-			},
-			ObjectMeta: new.ObjectMeta,
-			Spec:       new.Spec,
-		}
-		data, _ := meta.MarshalToJson(r, api.SchemeGroupVersion)
-		obj.Annotations[api.LastAppliedConfiguration] = string(data)
-		obj.Annotations[api.VersionTag] = c.options.SidecarImageTag
+		obj.Spec.Template.Spec = util.InjectSidecar(obj.Spec.Template.Spec, old, new, workload, c.options.SidecarImageTag, c.options.EnableRBAC)
+		obj.Annotations = util.SetAppliedRestic(obj.Annotations, new, c.options.SidecarImageTag)
+		obj.Annotations[api.PendingRollout] = "true"
 
 		return obj
 	})
 	if err != nil {
 		return
 	}
-
-	err = apps_util.WaitUntilStatefulSetReady(c.k8sClient, resource.ObjectMeta)
-	if err != nil {
-		return
-	}
-	err = util.WaitUntilSidecarAdded(c.k8sClient, resource.Namespace, resource.Spec.Selector, new.Spec.Type)
-	return err
+	return c.rolloutStatefulSet(resource)
 }
 
 func (c *StashController) EnsureStatefulSetSidecarDeleted(resource *apps.StatefulSet, restic *api.Restic) (err error) {
@@ -256,30 +240,50 @@ func (c *StashController) EnsureStatefulSetSidecarDeleted(resource *apps.Statefu
 	}
 
 	resource, _, err = apps_util.PatchStatefulSet(c.k8sClient, resource, func(obj *apps.StatefulSet) *apps.StatefulSet {
-		if restic.Spec.Type == api.BackupOffline {
-			obj.Spec.Template.Spec.InitContainers = core_util.EnsureContainerDeleted(obj.Spec.Template.Spec.InitContainers, util.StashContainer)
-		} else {
-			obj.Spec.Template.Spec.Containers = core_util.EnsureContainerDeleted(obj.Spec.Template.Spec.Containers, util.StashContainer)
-		}
-		obj.Spec.Template.Spec.Volumes = util.EnsureVolumeDeleted(obj.Spec.Template.Spec.Volumes, util.ScratchDirVolumeName)
-		obj.Spec.Template.Spec.Volumes = util.EnsureVolumeDeleted(obj.Spec.Template.Spec.Volumes, util.PodinfoVolumeName)
-		if restic.Spec.Backend.Local != nil {
-			obj.Spec.Template.Spec.Volumes = util.EnsureVolumeDeleted(obj.Spec.Template.Spec.Volumes, util.LocalVolumeName)
-		}
-		if obj.Annotations != nil {
-			delete(obj.Annotations, api.LastAppliedConfiguration)
-			delete(obj.Annotations, api.VersionTag)
+		obj.Spec.Template.Spec = util.RemoveSidecar(obj.Spec.Template.Spec, restic)
+		util.RemoveAppliedRestic(obj.Annotations)
+		if obj.Annotations == nil {
+			obj.Annotations = map[string]string{}
 		}
+		obj.Annotations[api.PendingRollout] = "true"
 		return obj
 	})
 	if err != nil {
 		return
 	}
+	return c.rolloutStatefulSet(resource)
+}
 
-	err = apps_util.WaitUntilStatefulSetReady(c.k8sClient, resource.ObjectMeta)
+// rolloutStatefulSetPeriod is the interval between checks of a StatefulSet whose pods are being replaced.
+const rolloutStatefulSetPeriod = 5 * time.Second
+
+// rolloutStatefulSet replaces pods of ss one at a time, so that stateful applications keep their
+// quorum. Instead of blocking the worker until all pods are replaced, ss is requeued until the
+// rollout is complete and then the PendingRollout annotation is removed.
+func (c *StashController) rolloutStatefulSet(ss *apps.StatefulSet) error {
+	done, err := util.RolloutStatefulSet(c.k8sClient, ss)
 	if err != nil {
-		return
+		return err
 	}
-	err = util.WaitUntilSidecarRemoved(c.k8sClient, resource.Namespace, resource.Spec.Selector, restic.Spec.Type)
-	return err
+	if !done {
+		key, err := cache.MetaNamespaceKeyFunc(ss)
+		if err != nil {
+			return err
+		}
+		c.ssQueue.AddAfter(key, rolloutStatefulSetPeriod)
+		return nil
+	}
+
+	_, _, err = apps_util.PatchStatefulSet(c.k8sClient, ss, func(obj *apps.StatefulSet) *apps.StatefulSet {
+		delete(obj.Annotations, api.PendingRollout)
+		return obj
+	})
+	if err != nil {
+		return err
+	}
+	if _, applied := ss.Annotations[api.LastAppliedConfiguration]; !applied {
+		util.DeleteConfigmapLock(c.k8sClient, ss.Namespace, api.LocalTypedReference{Kind: api.KindStatefulSet, Name: ss.Name})
+	}
+	log.Infof("Updated pods of StatefulSet %s/%s", ss.Namespace, ss.Name)
+	return nil
 }
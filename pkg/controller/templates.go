@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"github.com/appscode/go/log"
+	"github.com/appscode/kutil/meta"
+	api "github.com/appscode/stash/apis/stash/v1alpha1"
+	stash_listers "github.com/appscode/stash/listers/stash/v1alpha1"
+	"github.com/appscode/stash/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	rt "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+func (c *StashController) initBackupTemplateWatcher() {
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (rt.Object, error) {
+			return c.stashClient.BackupTemplates().List(options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return c.stashClient.BackupTemplates().Watch(options)
+		},
+	}
+
+	// Restics referencing a template are re-synced when it changes, which updates their
+	// status and the sidecars of their workloads.
+	enqueue := func(obj interface{}) {
+		name, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+		if err != nil {
+			return
+		}
+		c.enqueueRestics(func(r *api.Restic) bool {
+			return r.Spec.Template == name
+		})
+	}
+	c.btIndexer, c.btInformer = cache.NewIndexerInformer(lw, &api.BackupTemplate{}, c.options.ResyncPeriod, cache.ResourceEventHandlerFuncs{
+		AddFunc: enqueue,
+		UpdateFunc: func(old interface{}, new interface{}) {
+			oldObj, ok := old.(*api.BackupTemplate)
+			if !ok {
+				log.Errorln("Invalid BackupTemplate object")
+				return
+			}
+			newObj, ok := new.(*api.BackupTemplate)
+			if !ok {
+				log.Errorln("Invalid BackupTemplate object")
+				return
+			}
+			if !meta.Equal(oldObj.Spec, newObj.Spec) {
+				enqueue(new)
+			}
+		},
+		DeleteFunc: enqueue,
+	}, cache.Indexers{})
+	c.btLister = stash_listers.NewBackupTemplateLister(c.btIndexer)
+}
+
+func (c *StashController) initClusterRetentionPolicyWatcher() {
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (rt.Object, error) {
+			return c.stashClient.ClusterRetentionPolicies().List(options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return c.stashClient.ClusterRetentionPolicies().Watch(options)
+		},
+	}
+
+	enqueue := func(obj interface{}) {
+		name, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+		if err != nil {
+			return
+		}
+		c.enqueueRestics(func(r *api.Restic) bool {
+			for _, fg := range r.Spec.FileGroups {
+				if fg.RetentionPolicyName == name {
+					return true
+				}
+			}
+			return false
+		})
+	}
+	c.crpIndexer, c.crpInformer = cache.NewIndexerInformer(lw, &api.ClusterRetentionPolicy{}, c.options.ResyncPeriod, cache.ResourceEventHandlerFuncs{
+		AddFunc: enqueue,
+		UpdateFunc: func(old interface{}, new interface{}) {
+			oldObj, ok := old.(*api.ClusterRetentionPolicy)
+			if !ok {
+				log.Errorln("Invalid ClusterRetentionPolicy object")
+				return
+			}
+			newObj, ok := new.(*api.ClusterRetentionPolicy)
+			if !ok {
+				log.Errorln("Invalid ClusterRetentionPolicy object")
+				return
+			}
+			if !meta.Equal(oldObj.Spec, newObj.Spec) {
+				enqueue(new)
+			}
+		},
+		DeleteFunc: enqueue,
+	}, cache.Indexers{})
+	c.crpLister = stash_listers.NewClusterRetentionPolicyLister(c.crpIndexer)
+}
+
+func (c *StashController) enqueueRestics(match func(r *api.Restic) bool) {
+	restics, err := c.rstLister.List(labels.Everything())
+	if err != nil {
+		log.Errorln(err)
+		return
+	}
+	for _, restic := range restics {
+		if match(restic) {
+			key, err := cache.MetaNamespaceKeyFunc(restic)
+			if err == nil {
+				c.rstQueue.Add(key)
+			}
+		}
+	}
+}
+
+// resticTemplates returns the BackupTemplate spec and ClusterRetentionPolicies used by restic,
+// from the caches of the operator.
+func (c *StashController) resticTemplates(restic *api.Restic) (*api.BackupTemplateSpec, []api.RetentionPolicy, error) {
+	return util.ResticTemplates(restic, c.btLister.Get, c.crpLister.Get)
+}
+
+// resolveRestic returns restic resolved with the current BackupTemplate and
+// ClusterRetentionPolicies, which may not be stored in its status yet.
+func (c *StashController) resolveRestic(restic *api.Restic) (*api.Restic, error) {
+	template, policies, err := c.resticTemplates(restic)
+	if err != nil {
+		return nil, err
+	}
+	out := restic.DeepCopy()
+	out.Status.Template = template
+	out.Status.ClusterRetentionPolicies = policies
+	return out.Resolved(), nil
+}
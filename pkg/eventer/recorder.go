@@ -17,20 +17,27 @@ import (
 const (
 	EventReasonInvalidRestic                 = "InvalidRestic"
 	EventReasonInvalidRecovery               = "InvalidRecovery"
+	EventReasonFailedToResolveTemplate       = "FailedResolveTemplate"
 	EventReasonInvalidCronExpression         = "InvalidCronExpression"
 	EventReasonSuccessfulCronExpressionReset = "SuccessfulCronExpressionReset"
 	EventReasonSuccessfulBackup              = "SuccessfulBackup"
 	EventReasonFailedToBackup                = "FailedBackup"
+	EventReasonBackupTimeout                 = "BackupTimeout"
 	EventReasonSuccessfulRecovery            = "SuccessfulRecovery"
 	EventReasonFailedToRecover               = "FailedRecovery"
 	EventReasonSuccessfulCheck               = "SuccessfulCheck"
 	EventReasonFailedToCheck                 = "FailedCheck"
+	EventReasonSuccessfulMaintenance         = "SuccessfulMaintenance"
+	EventReasonFailedMaintenance             = "FailedMaintenance"
+	EventReasonMaintenanceJobCreated         = "MaintenanceJobCreated"
+	EventReasonRemovedStaleLock              = "RemovedStaleLock"
 	EventReasonFailedToRetention             = "FailedRetention"
 	EventReasonFailedToUpdate                = "FailedUpdateBackup"
 	EventReasonFailedCronJob                 = "FailedCronJob"
 	EventReasonFailedToDelete                = "FailedDelete"
 	EventReasonJobCreated                    = "RecoveryJobCreated"
-	EventReasonCheckJobCreated               = "CheckJobCreated"
+	EventReasonSuccessfulHook                = "SuccessfulHook"
+	EventReasonFailedToRunHook               = "FailedHook"
 )
 
 func NewEventRecorder(client kubernetes.Interface, component string) record.EventRecorder {
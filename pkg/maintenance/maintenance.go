@@ -0,0 +1,137 @@
+package maintenance
+
+import (
+	"fmt"
+	"time"
+
+	api "github.com/appscode/stash/apis/stash/v1alpha1"
+	cs "github.com/appscode/stash/client/typed/stash/v1alpha1"
+	stash_util "github.com/appscode/stash/client/typed/stash/v1alpha1/util"
+	"github.com/appscode/stash/pkg/cli"
+	"github.com/appscode/stash/pkg/eventer"
+	"github.com/appscode/stash/pkg/util"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	MaintenanceEventComponent = "stash-maintenance"
+)
+
+type Options struct {
+	Namespace      string
+	RepositoryName string
+	Task           api.MaintenanceTask
+	ReadDataSubset string
+}
+
+type Controller struct {
+	k8sClient   kubernetes.Interface
+	stashClient cs.StashV1alpha1Interface
+	opt         Options
+}
+
+func New(k8sClient kubernetes.Interface, stashClient cs.StashV1alpha1Interface, opt Options) *Controller {
+	return &Controller{
+		k8sClient:   k8sClient,
+		stashClient: stashClient,
+		opt:         opt,
+	}
+}
+
+// Run runs a maintenance task for a Repository and records the result in its status.
+func (c *Controller) Run() error {
+	repository, err := c.stashClient.Repositories(c.opt.Namespace).Get(c.opt.RepositoryName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	startTime := metav1.Now()
+	err = c.runTask(repository)
+	endTime := time.Now()
+
+	status := api.MaintenanceStatus{
+		Task:            c.opt.Task,
+		LastRunTime:     &startTime,
+		LastRunDuration: endTime.Sub(startTime.Time).String(),
+		ReadDataSubset:  c.opt.ReadDataSubset,
+	}
+	if err != nil {
+		status.Error = err.Error()
+		eventer.CreateEventWithLog(
+			c.k8sClient,
+			MaintenanceEventComponent,
+			repository.ObjectReference(),
+			core.EventTypeWarning,
+			eventer.EventReasonFailedMaintenance,
+			fmt.Sprintf("Failed to %s repository, reason: %s", c.opt.Task, err),
+		)
+	} else {
+		status.LastSuccessfulRunTime = &startTime
+		eventer.CreateEventWithLog(
+			c.k8sClient,
+			MaintenanceEventComponent,
+			repository.ObjectReference(),
+			core.EventTypeNormal,
+			eventer.EventReasonSuccessfulMaintenance,
+			fmt.Sprintf("Finished %s of repository", c.opt.Task),
+		)
+	}
+
+	if e2 := c.updateStatus(repository, status); e2 != nil {
+		if err == nil {
+			return e2
+		}
+		return fmt.Errorf("%s, failed to update status, reason: %s", err, e2)
+	}
+	return err
+}
+
+func (c *Controller) runTask(repository *api.Repository) error {
+	secret, err := c.k8sClient.CoreV1().Secrets(c.opt.Namespace).Get(repository.Spec.Backend.StorageSecretName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	resticCLI := cli.New("/tmp", false, repository.Spec.Hostname)
+	if err = resticCLI.SetupEnv(repository.Spec.Backend, secret, repository.Spec.Prefix); err != nil {
+		return err
+	}
+	var bandwidth *api.BandwidthLimit
+	if restic, err := c.stashClient.Restics(c.opt.Namespace).Get(repository.Spec.Restic, metav1.GetOptions{}); err == nil {
+		bandwidth = restic.Spec.Bandwidth
+	}
+	resticCLI.SetBandwidthLimit(bandwidth, &util.DefaultBandwidthLimit)
+
+	switch c.opt.Task {
+	case api.MaintenanceTaskCheck:
+		return resticCLI.Check(c.opt.ReadDataSubset)
+	case api.MaintenanceTaskPrune:
+		return resticCLI.Prune()
+	case api.MaintenanceTaskUnlock:
+		return resticCLI.Unlock()
+	case api.MaintenanceTaskRebuildIndex:
+		return resticCLI.RebuildIndex()
+	}
+	return fmt.Errorf("unknown maintenance task %s", c.opt.Task)
+}
+
+// updateStatus replaces the status of the task in repository. Sidecars update the
+// backup status of the same repository, so update is retried on conflict.
+func (c *Controller) updateStatus(repository *api.Repository, status api.MaintenanceStatus) error {
+	_, err := stash_util.TryUpdateRepository(c.stashClient, repository.ObjectMeta, func(in *api.Repository) *api.Repository {
+		for i := range in.Status.Maintenance {
+			if in.Status.Maintenance[i].Task == status.Task {
+				if status.LastSuccessfulRunTime == nil {
+					status.LastSuccessfulRunTime = in.Status.Maintenance[i].LastSuccessfulRunTime
+				}
+				in.Status.Maintenance[i] = status
+				return in
+			}
+		}
+		in.Status.Maintenance = append(in.Status.Maintenance, status)
+		return in
+	})
+	return err
+}
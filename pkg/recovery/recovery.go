@@ -2,6 +2,9 @@ package recovery
 
 import (
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"time"
 
 	"github.com/appscode/go/log"
@@ -10,6 +13,7 @@ import (
 	stash_util "github.com/appscode/stash/client/typed/stash/v1alpha1/util"
 	"github.com/appscode/stash/pkg/cli"
 	"github.com/appscode/stash/pkg/eventer"
+	"github.com/appscode/stash/pkg/util"
 	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -102,10 +106,26 @@ func (c *Controller) RecoverOrErr(recovery *api.Recovery) error {
 	if err = cli.SetupEnv(recovery.Spec.Backend, secret, smartPrefix); err != nil {
 		return err
 	}
+	cli.SetBandwidthLimit(recovery.Spec.Bandwidth, &util.DefaultBandwidthLimit)
 
 	var errRec error
 	for _, path := range recovery.Spec.Paths {
-		d, err := c.measure(cli.Restore, path, hostname)
+		d, err := c.measure(func() error {
+			snapshotID := recovery.Spec.Snapshot
+			if recovery.Spec.PointInTime != nil {
+				id, err := cli.FindSnapshot(path, hostname, recovery.Spec.Tags, recovery.Spec.PointInTime.Time)
+				if err != nil {
+					return err
+				}
+				log.Infof("Found snapshot %s of path %s for pointInTime %s\n", id, path, recovery.Spec.PointInTime)
+				snapshotID = id
+			}
+			target := recovery.Spec.RestoreTargetFor(path)
+			if len(target.Command) > 0 {
+				return dumpToCommand(cli, hostname, snapshotID, recovery.Spec.Tags, target)
+			}
+			return cli.Restore(hostname, snapshotID, recovery.Spec.Tags, target, stagingDir(recovery, target))
+		})
 		if err != nil {
 			errRec = err
 			eventer.CreateEventWithLog(
@@ -125,8 +145,43 @@ func (c *Controller) RecoverOrErr(recovery *api.Recovery) error {
 	return errRec
 }
 
-func (c *Controller) measure(f func(string, string) error, path, host string) (time.Duration, error) {
+// dumpToCommand pipes the content of target.Path from the snapshot into target.Command.
+func dumpToCommand(w *cli.ResticWrapper, host, snapshotID string, tags []string, target api.RestoreTarget) error {
+	cmd := exec.Command(target.Command[0], target.Command[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err = cmd.Start(); err != nil {
+		return err
+	}
+	if err = w.Dump(host, snapshotID, tags, target.Path, stdin); err == nil {
+		err = stdin.Close()
+	}
+	if err != nil {
+		// don't let the command run with partial data
+		cmd.Process.Kill()
+		cmd.Wait()
+		return err
+	}
+	return cmd.Wait()
+}
+
+// stagingDir returns the recovered volume containing target.TargetPath, so that restored files can
+// be moved into it without copying. Otherwise, the parent directory of target.TargetPath is used.
+func stagingDir(recovery *api.Recovery, target api.RestoreTarget) string {
+	for _, vol := range recovery.Spec.RecoveredVolumes {
+		if api.IsSubPath(vol.MountPath, target.TargetPath) {
+			return vol.MountPath
+		}
+	}
+	return filepath.Dir(filepath.Clean(target.TargetPath))
+}
+
+func (c *Controller) measure(f func() error) (time.Duration, error) {
 	startTime := time.Now()
-	err := f(path, host)
+	err := f()
 	return time.Now().Sub(startTime), err
 }
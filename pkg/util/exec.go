@@ -0,0 +1,124 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// ExecIntoPod runs command in a container of pod and returns its combined stdout and stderr.
+func ExecIntoPod(config *rest.Config, kubeClient kubernetes.Interface, namespace, pod, container string, command []string, timeout time.Duration) (string, error) {
+	var out bytes.Buffer
+	err := ExecIntoPodStream(config, kubeClient, namespace, pod, container, command, &out, &out, timeout)
+	return out.String(), err
+}
+
+// ExecIntoPodStream runs command in a container of pod and streams its stdout and stderr.
+// If timeout is zero, command can run as long as it needs. Otherwise, output of the command
+// is discarded after the timeout expires and ExecTimeoutError is returned.
+func ExecIntoPodStream(config *rest.Config, kubeClient kubernetes.Interface, namespace, pod, container string, command []string, stdout, stderr io.Writer, timeout time.Duration) error {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	err := ExecIntoPodStreamContext(ctx, config, kubeClient, namespace, pod, container, command, stdout, stderr)
+	if err == context.DeadlineExceeded {
+		return &ExecTimeoutError{Command: command, Timeout: timeout}
+	}
+	return err
+}
+
+// ExecIntoPodStreamContext is like ExecIntoPodStream, but returns ctx.Err() once ctx is done.
+// Output of the command is discarded from then on. The command is not killed and may still be
+// running inside the container.
+func ExecIntoPodStreamContext(ctx context.Context, config *rest.Config, kubeClient kubernetes.Interface, namespace, pod, container string, command []string, stdout, stderr io.Writer) error {
+	req := kubeClient.CoreV1().RESTClient().Post().
+		Namespace(namespace).
+		Resource("pods").
+		Name(pod).
+		SubResource("exec").
+		VersionedParams(&core.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	exec, err := remotecommand.NewSPDYExecutor(config, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+	if ctx.Done() == nil {
+		return exec.Stream(remotecommand.StreamOptions{
+			Stdout: stdout,
+			Stderr: stderr,
+		})
+	}
+
+	out := &closableWriter{w: stdout}
+	errOut := &closableWriter{w: stderr}
+	done := make(chan error, 1)
+	go func() {
+		done <- exec.Stream(remotecommand.StreamOptions{
+			Stdout: out,
+			Stderr: errOut,
+		})
+	}()
+	select {
+	case err = <-done:
+		return err
+	case <-ctx.Done():
+		out.Close()
+		errOut.Close()
+		return ctx.Err()
+	}
+}
+
+// ExecTimeoutError is returned by ExecIntoPodStream if command does not finish in time.
+// The command is not killed and may still be running inside the container.
+type ExecTimeoutError struct {
+	Command []string
+	Timeout time.Duration
+}
+
+func (e *ExecTimeoutError) Error() string {
+	return fmt.Sprintf("command %v timed out after %s", e.Command, e.Timeout)
+}
+
+func IsExecTimeout(err error) bool {
+	_, ok := err.(*ExecTimeoutError)
+	return ok
+}
+
+// closableWriter discards writes once closed, so that a stream still running after a
+// timeout or cancellation does not write into a buffer read by the caller.
+type closableWriter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closed bool
+}
+
+func (c *closableWriter) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return len(p), nil
+	}
+	return c.w.Write(p)
+}
+
+func (c *closableWriter) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+}
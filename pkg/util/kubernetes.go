@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"reflect"
 	"strings"
 	"time"
@@ -16,11 +17,13 @@ import (
 	stash_listers "github.com/appscode/stash/listers/stash/v1alpha1"
 	"github.com/appscode/stash/pkg/docker"
 	"github.com/cenkalti/backoff"
+	apps "k8s.io/api/apps/v1beta1"
 	batch "k8s.io/api/batch/v1"
 	core "k8s.io/api/core/v1"
 	kerr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -34,20 +37,26 @@ const (
 
 	RecoveryJobPrefix = "stash-recovery-"
 	KubectlCronPrefix = "stash-kubectl-cron-"
-	CheckJobPrefix    = "stash-check-"
 
-	AnnotationRestic    = "restic"
-	AnnotationRecovery  = "recovery"
-	AnnotationOperation = "operation"
+	AnnotationRestic     = "restic"
+	AnnotationRecovery   = "recovery"
+	AnnotationRepository = "repository"
+	AnnotationOperation  = "operation"
 
-	OperationRecovery   = "recovery"
-	OperationCheck      = "check"
-	OperationDeletePods = "delete-pods"
-	AppLabelStash       = "stash"
+	OperationRecovery    = "recovery"
+	OperationMaintenance = "maintenance"
+	OperationDeletePods  = "delete-pods"
+	AppLabelStash        = "stash"
 )
 
 var (
 	AnalyticsClientID string
+	// DefaultBandwidthLimit is set in the operator and passed to the stash commands run in pods,
+	// where it applies to limits not set in Restic or Recovery.
+	DefaultBandwidthLimit api.BandwidthLimit
+	// MaxConcurrentBackups is set in the operator and passed to sidecars. It limits the number of
+	// sidecars taking backup at the same time in a namespace. Unlimited if zero.
+	MaxConcurrentBackups int
 )
 
 func GetAppliedRestic(m map[string]string) (*api.Restic, error) {
@@ -95,11 +104,58 @@ func FindRestic(lister stash_listers.ResticLister, obj metav1.ObjectMeta) (*api.
 		}
 		return nil, errors.New(msg.String())
 	} else if len(result) == 1 {
-		return result[0], nil
+		// resolved, so that a change of its BackupTemplate updates the applied Restic
+		return result[0].Resolved(), nil
 	}
 	return nil, nil
 }
 
+// ResticTemplates returns the spec of the BackupTemplate referenced by restic and the
+// ClusterRetentionPolicies used by its fileGroups but not defined in the Restic or template,
+// to be stored in the status of the Restic. Missing ClusterRetentionPolicies are skipped,
+// as they are reported by the validation of the resolved Restic.
+func ResticTemplates(
+	restic *api.Restic,
+	getTemplate func(name string) (*api.BackupTemplate, error),
+	getPolicy func(name string) (*api.ClusterRetentionPolicy, error),
+) (*api.BackupTemplateSpec, []api.RetentionPolicy, error) {
+	var template *api.BackupTemplateSpec
+	defined := sets.NewString()
+	for _, policy := range restic.Spec.RetentionPolicies {
+		defined.Insert(policy.Name)
+	}
+	if restic.Spec.Template != "" {
+		t, err := getTemplate(restic.Spec.Template)
+		if kerr.IsNotFound(err) {
+			return nil, nil, fmt.Errorf("BackupTemplate %s is not found", restic.Spec.Template)
+		} else if err != nil {
+			return nil, nil, err
+		}
+		template = t.Spec.DeepCopy()
+		for _, policy := range template.RetentionPolicies {
+			defined.Insert(policy.Name)
+		}
+	}
+
+	var policies []api.RetentionPolicy
+	for _, fg := range restic.Spec.FileGroups {
+		if fg.RetentionPolicyName == "" || defined.Has(fg.RetentionPolicyName) {
+			continue
+		}
+		p, err := getPolicy(fg.RetentionPolicyName)
+		if kerr.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, nil, err
+		}
+		policy := *p.Spec.DeepCopy()
+		policy.Name = p.Name
+		policies = append(policies, policy)
+		defined.Insert(policy.Name)
+	}
+	return template, policies, nil
+}
+
 func WaitUntilSidecarAdded(kubeClient kubernetes.Interface, namespace string, selector *metav1.LabelSelector, backupType api.BackupType) error {
 	return backoff.Retry(func() error {
 		r, err := metav1.LabelSelectorAsSelector(selector)
@@ -186,6 +242,60 @@ func WaitUntilSidecarRemoved(kubeClient kubernetes.Interface, namespace string,
 	}, backoff.NewConstantBackOff(3*time.Second))
 }
 
+// RolloutStatefulSet makes progress replacing pods of a StatefulSet to run its current pod template,
+// without waiting. It returns true once all pods are updated. For RollingUpdate strategy, StatefulSet
+// controller replaces pods one at a time in reverse ordinal order and pods with ordinal less than
+// partition are left alone. For OnDelete strategy, pods are deleted here in the same order, one per
+// call, and only after the replacements of pods with higher ordinals are ready. So, unlike
+// WaitUntilSidecarAdded, pods of a StatefulSet are never restarted all at once.
+func RolloutStatefulSet(kubeClient kubernetes.Interface, ss *apps.StatefulSet) (bool, error) {
+	if ss.Status.ObservedGeneration == nil || *ss.Status.ObservedGeneration < ss.Generation {
+		return false, nil
+	}
+	replicas := types.Int32(ss.Spec.Replicas)
+
+	if ss.Spec.UpdateStrategy.Type != apps.OnDeleteStatefulSetStrategyType {
+		var partition int32
+		if ss.Spec.UpdateStrategy.RollingUpdate != nil {
+			partition = types.Int32(ss.Spec.UpdateStrategy.RollingUpdate.Partition)
+		}
+		return ss.Status.UpdatedReplicas >= replicas-partition && ss.Status.ReadyReplicas == replicas, nil
+	}
+
+	for i := replicas - 1; i >= 0; i-- {
+		name := fmt.Sprintf("%s-%d", ss.Name, i)
+		pod, err := kubeClient.CoreV1().Pods(ss.Namespace).Get(name, metav1.GetOptions{})
+		if kerr.IsNotFound(err) {
+			// being recreated by StatefulSet controller
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+		if pod.Labels[apps.StatefulSetRevisionLabel] != ss.Status.UpdateRevision {
+			if pod.DeletionTimestamp == nil {
+				err = kubeClient.CoreV1().Pods(ss.Namespace).Delete(name, &metav1.DeleteOptions{})
+				if err != nil && !kerr.IsNotFound(err) {
+					return false, fmt.Errorf("failed to delete pod %s/%s, reason: %s", ss.Namespace, name, err)
+				}
+			}
+			return false, nil
+		}
+		if !isPodReady(pod) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func isPodReady(pod *core.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == core.PodReady {
+			return cond.Status == core.ConditionTrue
+		}
+	}
+	return false
+}
+
 func GetString(m map[string]string, key string) string {
 	if m == nil {
 		return ""
@@ -205,9 +315,25 @@ func NewInitContainer(r *api.Restic, tag string, workload api.LocalTypedReferenc
 	if enableRBAC {
 		container.Args = append(container.Args, "--enable-rbac=true")
 	}
+	container.Args = append(container.Args, bandwidthLimitArgs()...)
+	if MaxConcurrentBackups > 0 {
+		container.Args = append(container.Args, fmt.Sprintf("--max-concurrent-backups=%d", MaxConcurrentBackups))
+	}
 	return container
 }
 
+// bandwidthLimitArgs returns the flags passing DefaultBandwidthLimit to stash commands.
+func bandwidthLimitArgs() []string {
+	var args []string
+	if DefaultBandwidthLimit.Upload > 0 {
+		args = append(args, fmt.Sprintf("--limit-upload=%d", DefaultBandwidthLimit.Upload))
+	}
+	if DefaultBandwidthLimit.Download > 0 {
+		args = append(args, fmt.Sprintf("--limit-download=%d", DefaultBandwidthLimit.Download))
+	}
+	return args
+}
+
 func NewSidecarContainer(r *api.Restic, tag string, workload api.LocalTypedReference) core.Container {
 	if r.Annotations != nil {
 		if v, ok := r.Annotations[api.VersionTag]; ok {
@@ -277,6 +403,10 @@ func NewSidecarContainer(r *api.Restic, tag string, workload api.LocalTypedRefer
 		_, mnt := r.Spec.Backend.Local.ToVolumeAndMount(LocalVolumeName)
 		sidecar.VolumeMounts = append(sidecar.VolumeMounts, mnt)
 	}
+	sidecar.Args = append(sidecar.Args, bandwidthLimitArgs()...)
+	if MaxConcurrentBackups > 0 {
+		sidecar.Args = append(sidecar.Args, fmt.Sprintf("--max-concurrent-backups=%d", MaxConcurrentBackups))
+	}
 	return sidecar
 }
 
@@ -333,6 +463,62 @@ func MergeLocalVolume(volumes []core.Volume, old, new *api.Restic) []core.Volume
 	return volumes
 }
 
+// InjectSidecar adds the stash container for restic and the volumes used by it to pod spec of workload.
+// Offline backup uses an init container instead of a sidecar.
+func InjectSidecar(spec core.PodSpec, old, new *api.Restic, workload api.LocalTypedReference, tag string, enableRBAC bool) core.PodSpec {
+	if new.Spec.Type == api.BackupOffline {
+		spec.InitContainers = core_util.UpsertContainer(spec.InitContainers, NewInitContainer(new, tag, workload, enableRBAC))
+	} else {
+		spec.Containers = core_util.UpsertContainer(spec.Containers, NewSidecarContainer(new, tag, workload))
+	}
+	spec.Volumes = UpsertScratchVolume(spec.Volumes)
+	spec.Volumes = UpsertDownwardVolume(spec.Volumes)
+	spec.Volumes = MergeLocalVolume(spec.Volumes, old, new)
+	return spec
+}
+
+// RemoveSidecar removes the stash container and volumes added by InjectSidecar from pod spec.
+func RemoveSidecar(spec core.PodSpec, restic *api.Restic) core.PodSpec {
+	if restic.Spec.Type == api.BackupOffline {
+		spec.InitContainers = core_util.EnsureContainerDeleted(spec.InitContainers, StashContainer)
+	} else {
+		spec.Containers = core_util.EnsureContainerDeleted(spec.Containers, StashContainer)
+	}
+	spec.Volumes = EnsureVolumeDeleted(spec.Volumes, ScratchDirVolumeName)
+	spec.Volumes = EnsureVolumeDeleted(spec.Volumes, PodinfoVolumeName)
+	if restic.Spec.Backend.Local != nil {
+		spec.Volumes = EnsureVolumeDeleted(spec.Volumes, LocalVolumeName)
+	}
+	return spec
+}
+
+// SetAppliedRestic records restic and sidecar image tag in workload annotations.
+// GetAppliedRestic reads it back.
+func SetAppliedRestic(annotations map[string]string, restic *api.Restic, tag string) map[string]string {
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	r := &api.Restic{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: api.SchemeGroupVersion.String(),
+			Kind:       api.ResourceKindRestic,
+		},
+		ObjectMeta: restic.ObjectMeta,
+		Spec:       restic.Spec,
+	}
+	data, _ := meta.MarshalToJson(r, api.SchemeGroupVersion)
+	annotations[api.LastAppliedConfiguration] = string(data)
+	annotations[api.VersionTag] = tag
+	return annotations
+}
+
+func RemoveAppliedRestic(annotations map[string]string) {
+	if annotations != nil {
+		delete(annotations, api.LastAppliedConfiguration)
+		delete(annotations, api.VersionTag)
+	}
+}
+
 func EnsureVolumeDeleted(volumes []core.Volume, name string) []core.Volume {
 	for i, v := range volumes {
 		if v.Name == name {
@@ -398,11 +584,11 @@ func NewRecoveryJob(recovery *api.Recovery, tag string) *batch.Job {
 						{
 							Name:  StashContainer,
 							Image: docker.ImageOperator + ":" + tag,
-							Args: []string{
+							Args: append([]string{
 								"recover",
 								"--recovery-name=" + recovery.Name,
 								"--v=10",
-							},
+							}, bandwidthLimitArgs()...),
 							Env: []core.EnvVar{
 								{
 									Name:  analytics.Key,
@@ -488,11 +674,50 @@ func DeleteConfigmapLock(k8sClient kubernetes.Interface, namespace string, workl
 	return k8sClient.CoreV1().ConfigMaps(namespace).Delete(GetConfigmapLockName(workload), &metav1.DeleteOptions{})
 }
 
-func NewCheckJob(restic *api.Restic, hostName string, smartPrefix string, tag string) *batch.Job {
+// maxJobNameLength is the limit of label values, as the name of a Job is used in the job-name
+// label of its pods.
+const maxJobNameLength = 63
+
+// MaintenanceJobName returns the name of the Job running task for a Repository. Only one Job
+// runs a task for a Repository at a time. Names of Repositories of DaemonSets and StatefulSets
+// include the node or pod name, so long names are truncated and suffixed with a hash of the
+// Repository name to keep them unique.
+func MaintenanceJobName(task api.MaintenanceTask, repository string) string {
+	name := "stash-" + string(task) + "-" + repository
+	if len(name) <= maxJobNameLength {
+		return name
+	}
+	hash := nameHash(repository)
+	return strings.TrimRight(name[:maxJobNameLength-len(hash)-1], "-.") + "-" + hash
+}
+
+// nameHash returns a short hash of name, which can be used as a label value.
+func nameHash(name string) string {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return fmt.Sprintf("%08x", h.Sum32())
+}
+
+// NewMaintenanceJob returns a Job that runs task for repository. readDataSubset is passed
+// to restic check, if not empty.
+func NewMaintenanceJob(restic *api.Restic, repository *api.Repository, task api.MaintenanceTask, readDataSubset string, tag string) *batch.Job {
+	args := []string{
+		"maintain",
+		"--repository=" + repository.Name,
+		"--task=" + string(task),
+	}
+	if readDataSubset != "" {
+		args = append(args, "--read-data-subset="+readDataSubset)
+	}
+	args = append(args, "--v=10")
+	args = append(args, bandwidthLimitArgs()...)
+	// failed tasks are run again on the next schedule
+	backoffLimit := int32(0)
+
 	job := &batch.Job{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      CheckJobPrefix + restic.Name,
-			Namespace: restic.Namespace,
+			Name:      MaintenanceJobName(task, repository.Name),
+			Namespace: repository.Namespace,
 			OwnerReferences: []metav1.OwnerReference{
 				{
 					APIVersion: api.SchemeGroupVersion.String(),
@@ -501,26 +726,26 @@ func NewCheckJob(restic *api.Restic, hostName string, smartPrefix string, tag st
 					UID:        restic.UID,
 				},
 			},
+			// the name of the Repository may be too long for a label value
 			Labels: map[string]string{
-				"app":               AppLabelStash,
-				AnnotationRestic:    restic.Name,
-				AnnotationOperation: OperationCheck,
+				"app":                AppLabelStash,
+				AnnotationRestic:     restic.Name,
+				AnnotationRepository: nameHash(repository.Name),
+				AnnotationOperation:  OperationMaintenance,
+			},
+			Annotations: map[string]string{
+				AnnotationRepository: repository.Name,
 			},
 		},
 		Spec: batch.JobSpec{
+			BackoffLimit: &backoffLimit,
 			Template: core.PodTemplateSpec{
 				Spec: core.PodSpec{
 					Containers: []core.Container{
 						{
 							Name:  StashContainer,
 							Image: docker.ImageOperator + ":" + tag,
-							Args: []string{
-								"check",
-								"--restic-name=" + restic.Name,
-								"--host-name=" + hostName,
-								"--smart-prefix=" + smartPrefix,
-								"--v=10",
-							},
+							Args:  args,
 							Env: []core.EnvVar{
 								{
 									Name:  analytics.Key,
@@ -535,7 +760,7 @@ func NewCheckJob(restic *api.Restic, hostName string, smartPrefix string, tag st
 							},
 						},
 					},
-					RestartPolicy: core.RestartPolicyOnFailure,
+					RestartPolicy: core.RestartPolicyNever,
 					Volumes: []core.Volume{
 						{
 							Name: ScratchDirVolumeName,
@@ -550,9 +775,8 @@ func NewCheckJob(restic *api.Restic, hostName string, smartPrefix string, tag st
 	}
 
 	// local backend
-	// user don't need to specify "stash-local" volume, we collect it from restic-spec
-	if restic.Spec.Backend.Local != nil {
-		vol, mnt := restic.Spec.Backend.Local.ToVolumeAndMount(LocalVolumeName)
+	if repository.Spec.Backend.Local != nil {
+		vol, mnt := repository.Spec.Backend.Local.ToVolumeAndMount(LocalVolumeName)
 		job.Spec.Template.Spec.Containers[0].VolumeMounts = append(
 			job.Spec.Template.Spec.Containers[0].VolumeMounts, mnt)
 		job.Spec.Template.Spec.Volumes = append(job.Spec.Template.Spec.Volumes, vol)
@@ -0,0 +1,38 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	api "github.com/appscode/stash/apis/stash/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestMaintenanceJobName(t *testing.T) {
+	long := "statefulset.mysql-0." + strings.Repeat("a", 60)
+	cases := []struct {
+		task       api.MaintenanceTask
+		repository string
+		expected   string
+	}{
+		{api.MaintenanceTaskCheck, "deployment.nginx", "stash-check-deployment.nginx"},
+		{api.MaintenanceTaskRebuildIndex, "daemonset.fluentd.node-1", "stash-rebuild-index-daemonset.fluentd.node-1"},
+		{api.MaintenanceTaskPrune, long, ""},
+	}
+	for _, c := range cases {
+		name := MaintenanceJobName(c.task, c.repository)
+		if c.expected != "" && name != c.expected {
+			t.Errorf("%s %s: expected %s, got %s", c.task, c.repository, c.expected, name)
+		}
+		if errs := validation.IsValidLabelValue(name); len(errs) > 0 {
+			t.Errorf("%s %s: %s is not a valid label value: %v", c.task, c.repository, name, errs)
+		}
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			t.Errorf("%s %s: %s is not a valid name: %v", c.task, c.repository, name, errs)
+		}
+	}
+
+	if MaintenanceJobName(api.MaintenanceTaskPrune, long+"b") == MaintenanceJobName(api.MaintenanceTaskPrune, long+"c") {
+		t.Errorf("truncated names of different repositories are equal")
+	}
+}
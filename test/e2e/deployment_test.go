@@ -356,6 +356,33 @@ var _ = Describe("Deployment", func() {
 			It(`should backup new Deployment`, shouldBackupNewDeployment)
 			It(`should backup existing Deployment`, shouldBackupExistingDeployment)
 		})
+
+		Context(`"Rest" backend`, func() {
+			BeforeEach(func() {
+				cred = f.SecretForRestBackend()
+				restic = f.ResticForRestBackend()
+			})
+			It(`should backup new Deployment`, shouldBackupNewDeployment)
+			It(`should backup existing Deployment`, shouldBackupExistingDeployment)
+		})
+
+		Context(`"SFTP" backend`, func() {
+			BeforeEach(func() {
+				cred = f.SecretForSFTPBackend()
+				restic = f.ResticForSFTPBackend()
+			})
+			It(`should backup new Deployment`, shouldBackupNewDeployment)
+			It(`should backup existing Deployment`, shouldBackupExistingDeployment)
+		})
+
+		Context(`"Minio" backend`, func() {
+			BeforeEach(func() {
+				cred = f.SecretForMinioBackend()
+				restic = f.ResticForMinioBackend()
+			})
+			It(`should backup new Deployment`, shouldBackupNewDeployment)
+			It(`should backup existing Deployment`, shouldBackupExistingDeployment)
+		})
 	})
 
 	Describe("Changing Deployment labels", func() {
@@ -626,4 +653,67 @@ var _ = Describe("Deployment", func() {
 			It(`should backup new Deployment`, shouldBackupNewDeployment)
 		})
 	})
+
+	Describe("Creating BackupSession", func() {
+		var session api.BackupSession
+
+		AfterEach(func() {
+			f.DeleteBackupSession(session.ObjectMeta)
+			f.DeleteDeployment(deployment.ObjectMeta)
+			f.DeleteRestic(restic.ObjectMeta)
+			f.DeleteSecret(cred.ObjectMeta)
+		})
+
+		Context(`"Local" backend`, func() {
+			BeforeEach(func() {
+				cred = f.SecretForLocalBackend()
+				restic = f.ResticForLocalBackend()
+				restic.Spec.Schedule = "@every 24h"
+			})
+			It(`should backup Deployment on demand`, func() {
+				By("Creating repository Secret " + cred.Name)
+				err = f.CreateSecret(cred)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("Creating restic " + restic.Name)
+				err = f.CreateRestic(restic)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("Creating Deployment " + deployment.Name)
+				_, err = f.CreateDeployment(deployment)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("Waiting for sidecar")
+				f.EventuallyDeployment(deployment.ObjectMeta).Should(HaveSidecar(util.StashContainer))
+
+				By("Waiting for sidecar to start")
+				f.EventuallyDeployment(deployment.ObjectMeta).Should(WithTransform(func(obj *apps.Deployment) bool {
+					return obj.Status.UpdatedReplicas == *obj.Spec.Replicas && obj.Status.AvailableReplicas == *obj.Spec.Replicas
+				}, BeTrue()))
+
+				session = f.BackupSessionForRestic(restic)
+				By("Creating BackupSession " + session.Name)
+				err = f.CreateBackupSession(session)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("Waiting for BackupSession to succeed")
+				f.EventuallyBackupSession(session.ObjectMeta).Should(WithTransform(func(obj *api.BackupSession) api.BackupSessionPhase {
+					return obj.Status.Phase
+				}, Equal(api.BackupSessionSucceeded)))
+
+				By("Waiting for backup to complete")
+				f.EventuallyRestic(restic.ObjectMeta).Should(WithTransform(func(r *api.Restic) bool {
+					return r.Status.BackupCount >= 1 && r.Status.LastSuccessfulBackupTime != nil
+				}, BeTrue()))
+
+				By("Checking snapshot ID is recorded")
+				f.EventuallyBackupSession(session.ObjectMeta).Should(WithTransform(func(obj *api.BackupSession) string {
+					if len(obj.Status.Hosts) == 0 || len(obj.Status.Hosts[0].FileGroups) == 0 {
+						return ""
+					}
+					return obj.Status.Hosts[0].FileGroups[0].SnapshotID
+				}, Not(BeEmpty())))
+			})
+		})
+	})
 })
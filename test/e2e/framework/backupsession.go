@@ -0,0 +1,43 @@
+package framework
+
+import (
+	"time"
+
+	"github.com/appscode/go/crypto/rand"
+	api "github.com/appscode/stash/apis/stash/v1alpha1"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (fi *Invocation) BackupSessionForRestic(restic api.Restic) api.BackupSession {
+	return api.BackupSession{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: api.SchemeGroupVersion.String(),
+			Kind:       api.ResourceKindBackupSession,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rand.WithUniqSuffix("stash"),
+			Namespace: fi.namespace,
+		},
+		Spec: api.BackupSessionSpec{
+			Restic: restic.Name,
+		},
+	}
+}
+
+func (f *Framework) CreateBackupSession(obj api.BackupSession) error {
+	_, err := f.StashClient.BackupSessions(obj.Namespace).Create(&obj)
+	return err
+}
+
+func (f *Framework) DeleteBackupSession(meta metav1.ObjectMeta) error {
+	return f.StashClient.BackupSessions(meta.Namespace).Delete(meta.Name, deleteInBackground())
+}
+
+func (f *Framework) EventuallyBackupSession(meta metav1.ObjectMeta) GomegaAsyncAssertion {
+	return Eventually(func() *api.BackupSession {
+		obj, err := f.StashClient.BackupSessions(meta.Namespace).Get(meta.Name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		return obj
+	}, time.Minute*5, time.Second*5)
+}
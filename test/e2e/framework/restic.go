@@ -1,6 +1,9 @@
 package framework
 
 import (
+	"os"
+	"strconv"
+
 	"github.com/appscode/go/crypto/rand"
 	api "github.com/appscode/stash/apis/stash/v1alpha1"
 	stash_util "github.com/appscode/stash/client/typed/stash/v1alpha1/util"
@@ -104,6 +107,21 @@ func (fi *Invocation) ResticForDOBackend() api.Restic {
 	return r
 }
 
+func (fi *Invocation) ResticForMinioBackend() api.Restic {
+	r := fi._restic()
+	r.Spec.Backend = api.Backend{
+		StorageSecretName: "",
+		S3: &api.S3Spec{
+			Endpoint:       os.Getenv(MINIO_ENDPOINT),
+			Bucket:         "stash-qa",
+			Prefix:         fi.app,
+			Region:         "us-east-1",
+			ForcePathStyle: true,
+		},
+	}
+	return r
+}
+
 func (fi *Invocation) ResticForGCSBackend() api.Restic {
 	r := fi._restic()
 	r.Spec.Backend = api.Backend{
@@ -152,6 +170,32 @@ func (fi *Invocation) ResticForB2Backend() api.Restic {
 	return r
 }
 
+func (fi *Invocation) ResticForRestBackend() api.Restic {
+	r := fi._restic()
+	r.Spec.Backend = api.Backend{
+		StorageSecretName: "",
+		Rest: &api.RestServerSpec{
+			URL: os.Getenv(REST_SERVER_URL),
+		},
+	}
+	return r
+}
+
+func (fi *Invocation) ResticForSFTPBackend() api.Restic {
+	r := fi._restic()
+	port, _ := strconv.Atoi(os.Getenv(SFTP_PORT))
+	r.Spec.Backend = api.Backend{
+		StorageSecretName: "",
+		SFTP: &api.SFTPSpec{
+			Host: os.Getenv(SFTP_HOST),
+			Port: port,
+			User: os.Getenv(SFTP_USER),
+			Path: "/tmp/stash-qa/" + fi.app,
+		},
+	}
+	return r
+}
+
 func (f *Framework) CreateRestic(obj api.Restic) error {
 	_, err := f.StashClient.Restics(obj.Namespace).Create(&obj)
 	return err
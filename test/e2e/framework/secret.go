@@ -12,6 +12,15 @@ import (
 
 const (
 	TEST_RESTIC_PASSWORD = "not@secret"
+
+	REST_SERVER_URL          = "REST_SERVER_URL"
+	REST_SERVER_CA_CERT_FILE = "REST_SERVER_CA_CERT_FILE"
+
+	SFTP_HOST             = "SFTP_HOST"
+	SFTP_PORT             = "SFTP_PORT"
+	SFTP_USER             = "SFTP_USER"
+	SFTP_PRIVATE_KEY_FILE = "SFTP_PRIVATE_KEY_FILE"
+	SFTP_KNOWN_HOSTS_FILE = "SFTP_KNOWN_HOSTS_FILE"
 )
 
 func (fi *Invocation) SecretForLocalBackend() core.Secret {
@@ -69,6 +78,39 @@ func (fi *Invocation) SecretForDOBackend() core.Secret {
 	}
 }
 
+const (
+	MINIO_ENDPOINT          = "MINIO_ENDPOINT"
+	MINIO_ACCESS_KEY_ID     = "MINIO_ACCESS_KEY_ID"
+	MINIO_SECRET_ACCESS_KEY = "MINIO_SECRET_ACCESS_KEY"
+	MINIO_CA_CERT_FILE      = "MINIO_CA_CERT_FILE"
+)
+
+func (fi *Invocation) SecretForMinioBackend() core.Secret {
+	if os.Getenv(MINIO_ENDPOINT) == "" ||
+		os.Getenv(MINIO_ACCESS_KEY_ID) == "" ||
+		os.Getenv(MINIO_SECRET_ACCESS_KEY) == "" {
+		return core.Secret{}
+	}
+
+	secret := core.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rand.WithUniqSuffix(fi.app + "-minio"),
+			Namespace: fi.namespace,
+		},
+		Data: map[string][]byte{
+			cli.RESTIC_PASSWORD:       []byte(TEST_RESTIC_PASSWORD),
+			cli.AWS_ACCESS_KEY_ID:     []byte(os.Getenv(MINIO_ACCESS_KEY_ID)),
+			cli.AWS_SECRET_ACCESS_KEY: []byte(os.Getenv(MINIO_SECRET_ACCESS_KEY)),
+		},
+	}
+	if caFile := os.Getenv(MINIO_CA_CERT_FILE); caFile != "" {
+		if caCert, err := ioutil.ReadFile(caFile); err == nil {
+			secret.Data[cli.CA_CERT_DATA] = caCert
+		}
+	}
+	return secret
+}
+
 func (fi *Invocation) SecretForGCSBackend() core.Secret {
 	if os.Getenv(cli.GOOGLE_PROJECT_ID) == "" ||
 		(os.Getenv(cli.GOOGLE_APPLICATION_CREDENTIALS) == "" && os.Getenv(cli.GOOGLE_SERVICE_ACCOUNT_JSON_KEY) == "") {
@@ -157,7 +199,59 @@ func (fi *Invocation) SecretForB2Backend() core.Secret {
 	}
 }
 
-// TODO: Add more methods for Swift, Backblaze B2, Rest server backend.
+func (fi *Invocation) SecretForRestBackend() core.Secret {
+	if os.Getenv(REST_SERVER_URL) == "" {
+		return core.Secret{}
+	}
+
+	secret := core.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rand.WithUniqSuffix(fi.app + "-rest"),
+			Namespace: fi.namespace,
+		},
+		Data: map[string][]byte{
+			cli.RESTIC_PASSWORD: []byte(TEST_RESTIC_PASSWORD),
+		},
+	}
+	for _, key := range []string{cli.REST_SERVER_USERNAME, cli.REST_SERVER_PASSWORD} {
+		if v := os.Getenv(key); v != "" {
+			secret.Data[key] = []byte(v)
+		}
+	}
+	if caFile := os.Getenv(REST_SERVER_CA_CERT_FILE); caFile != "" {
+		if caCert, err := ioutil.ReadFile(caFile); err == nil {
+			secret.Data[cli.CA_CERT_DATA] = caCert
+		}
+	}
+	return secret
+}
+
+func (fi *Invocation) SecretForSFTPBackend() core.Secret {
+	if os.Getenv(SFTP_HOST) == "" ||
+		os.Getenv(SFTP_USER) == "" {
+		return core.Secret{}
+	}
+	privateKey, err := ioutil.ReadFile(os.Getenv(SFTP_PRIVATE_KEY_FILE))
+	if err != nil {
+		return core.Secret{}
+	}
+	knownHosts, err := ioutil.ReadFile(os.Getenv(SFTP_KNOWN_HOSTS_FILE))
+	if err != nil {
+		return core.Secret{}
+	}
+
+	return core.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rand.WithUniqSuffix(fi.app + "-sftp"),
+			Namespace: fi.namespace,
+		},
+		Data: map[string][]byte{
+			cli.RESTIC_PASSWORD: []byte(TEST_RESTIC_PASSWORD),
+			cli.SSH_PRIVATE_KEY: privateKey,
+			cli.SSH_KNOWN_HOSTS: knownHosts,
+		},
+	}
+}
 
 func (f *Framework) CreateSecret(obj core.Secret) error {
 	_, err := f.KubeClient.CoreV1().Secrets(obj.Namespace).Create(&obj)
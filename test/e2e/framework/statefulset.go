@@ -11,11 +11,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func (fi *Invocation) StatefulSet(r api.Restic, sidecarImageTag string) apps.StatefulSet {
-	resource := apps.StatefulSet{
+// StatefulSetWithoutSidecar returns a StatefulSet which gets its sidecar from Stash operator.
+func (fi *Invocation) StatefulSetWithoutSidecar() apps.StatefulSet {
+	return apps.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      rand.WithUniqSuffix("stash"),
-			Namespace: r.Namespace,
+			Namespace: fi.namespace,
 			Labels: map[string]string{
 				"app": fi.app,
 			},
@@ -29,6 +30,11 @@ func (fi *Invocation) StatefulSet(r api.Restic, sidecarImageTag string) apps.Sta
 			},
 		},
 	}
+}
+
+func (fi *Invocation) StatefulSet(r api.Restic, sidecarImageTag string) apps.StatefulSet {
+	resource := fi.StatefulSetWithoutSidecar()
+	resource.Namespace = r.Namespace
 
 	workload := api.LocalTypedReference{
 		Kind: api.KindStatefulSet,
@@ -1,6 +1,9 @@
 package e2e_test
 
 import (
+	"time"
+
+	"github.com/appscode/go/types"
 	apps_util "github.com/appscode/kutil/apps/v1beta1"
 	api "github.com/appscode/stash/apis/stash/v1alpha1"
 	"github.com/appscode/stash/pkg/util"
@@ -39,6 +42,8 @@ var _ = Describe("StatefulSet", func() {
 
 	var (
 		shouldBackupNewStatefulSet = func() {
+			ss = f.StatefulSetWithoutSidecar()
+
 			By("Creating repository Secret " + cred.Name)
 			err = f.CreateSecret(cred)
 			Expect(err).NotTo(HaveOccurred())
@@ -68,6 +73,8 @@ var _ = Describe("StatefulSet", func() {
 		}
 
 		shouldBackupExistingStatefulSet = func() {
+			ss = f.StatefulSetWithoutSidecar()
+
 			By("Creating repository Secret " + cred.Name)
 			err = f.CreateSecret(cred)
 			Expect(err).NotTo(HaveOccurred())
@@ -203,6 +210,56 @@ var _ = Describe("StatefulSet", func() {
 			f.EventuallyStatefulSet(ss.ObjectMeta).ShouldNot(HaveSidecar(util.StashContainer))
 		}
 
+		shouldReplacePodsOneByOne = func() {
+			ss = f.StatefulSetWithoutSidecar()
+			ss.Spec.Replicas = types.Int32P(2)
+			ss.Spec.UpdateStrategy = apps.StatefulSetUpdateStrategy{
+				Type: apps.OnDeleteStatefulSetStrategyType,
+			}
+
+			By("Creating repository Secret " + cred.Name)
+			err = f.CreateSecret(cred)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Creating service " + svc.Name)
+			err = f.CreateService(svc)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Creating StatefulSet " + ss.Name)
+			_, err = f.CreateStatefulSet(ss)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Creating restic " + restic.Name)
+			err = f.CreateRestic(restic)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Waiting for sidecar")
+			f.EventuallyStatefulSet(ss.ObjectMeta).Should(HaveSidecar(util.StashContainer))
+
+			By("Waiting for sidecar in all pods")
+			for _, ordinal := range []string{"0", "1"} {
+				podName, err := api.StatefulSetPodName(ss.Name, ordinal)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(func() bool {
+					pod, err := f.KubeClient.CoreV1().Pods(ss.Namespace).Get(podName, metav1.GetOptions{})
+					if err != nil {
+						return false
+					}
+					for _, c := range pod.Spec.Containers {
+						if c.Name == util.StashContainer {
+							return true
+						}
+					}
+					return false
+				}, 5*time.Minute, 5*time.Second).Should(BeTrue())
+			}
+
+			By("Waiting for backup to complete")
+			f.EventuallyRestic(restic.ObjectMeta).Should(WithTransform(func(r *api.Restic) int64 {
+				return r.Status.BackupCount
+			}, BeNumerically(">=", 2)))
+		}
+
 		shouldRestoreStatefulSet = func() {
 			shouldBackupNewStatefulSet()
 			recovery.Spec.Workload = api.LocalTypedReference{
@@ -262,8 +319,8 @@ var _ = Describe("StatefulSet", func() {
 				cred = f.SecretForLocalBackend()
 				restic = f.ResticForLocalBackend()
 			})
-			XIt(`should backup new StatefulSet`, shouldBackupNewStatefulSet)
-			XIt(`should backup existing StatefulSet`, shouldBackupExistingStatefulSet)
+			It(`should backup new StatefulSet`, shouldBackupNewStatefulSet)
+			It(`should backup existing StatefulSet`, shouldBackupExistingStatefulSet)
 		})
 
 		Context(`"S3" backend`, func() {
@@ -271,8 +328,8 @@ var _ = Describe("StatefulSet", func() {
 				cred = f.SecretForS3Backend()
 				restic = f.ResticForS3Backend()
 			})
-			XIt(`should backup new StatefulSet`, shouldBackupNewStatefulSet)
-			XIt(`should backup existing StatefulSet`, shouldBackupExistingStatefulSet)
+			It(`should backup new StatefulSet`, shouldBackupNewStatefulSet)
+			It(`should backup existing StatefulSet`, shouldBackupExistingStatefulSet)
 		})
 
 		Context(`"DO" backend`, func() {
@@ -280,8 +337,8 @@ var _ = Describe("StatefulSet", func() {
 				cred = f.SecretForDOBackend()
 				restic = f.ResticForDOBackend()
 			})
-			XIt(`should backup new StatefulSet`, shouldBackupNewStatefulSet)
-			XIt(`should backup existing StatefulSet`, shouldBackupExistingStatefulSet)
+			It(`should backup new StatefulSet`, shouldBackupNewStatefulSet)
+			It(`should backup existing StatefulSet`, shouldBackupExistingStatefulSet)
 		})
 
 		Context(`"GCS" backend`, func() {
@@ -289,8 +346,8 @@ var _ = Describe("StatefulSet", func() {
 				cred = f.SecretForGCSBackend()
 				restic = f.ResticForGCSBackend()
 			})
-			XIt(`should backup new StatefulSet`, shouldBackupNewStatefulSet)
-			XIt(`should backup existing StatefulSet`, shouldBackupExistingStatefulSet)
+			It(`should backup new StatefulSet`, shouldBackupNewStatefulSet)
+			It(`should backup existing StatefulSet`, shouldBackupExistingStatefulSet)
 		})
 
 		Context(`"Azure" backend`, func() {
@@ -298,8 +355,8 @@ var _ = Describe("StatefulSet", func() {
 				cred = f.SecretForAzureBackend()
 				restic = f.ResticForAzureBackend()
 			})
-			XIt(`should backup new StatefulSet`, shouldBackupNewStatefulSet)
-			XIt(`should backup existing StatefulSet`, shouldBackupExistingStatefulSet)
+			It(`should backup new StatefulSet`, shouldBackupNewStatefulSet)
+			It(`should backup existing StatefulSet`, shouldBackupExistingStatefulSet)
 		})
 
 		Context(`"Swift" backend`, func() {
@@ -307,8 +364,8 @@ var _ = Describe("StatefulSet", func() {
 				cred = f.SecretForSwiftBackend()
 				restic = f.ResticForSwiftBackend()
 			})
-			XIt(`should backup new StatefulSet`, shouldBackupNewStatefulSet)
-			XIt(`should backup existing StatefulSet`, shouldBackupExistingStatefulSet)
+			It(`should backup new StatefulSet`, shouldBackupNewStatefulSet)
+			It(`should backup existing StatefulSet`, shouldBackupExistingStatefulSet)
 		})
 
 		Context(`"B2" backend`, func() {
@@ -316,8 +373,8 @@ var _ = Describe("StatefulSet", func() {
 				cred = f.SecretForB2Backend()
 				restic = f.ResticForB2Backend()
 			})
-			XIt(`should backup new StatefulSet`, shouldBackupNewStatefulSet)
-			XIt(`should backup existing StatefulSet`, shouldBackupExistingStatefulSet)
+			It(`should backup new StatefulSet`, shouldBackupNewStatefulSet)
+			It(`should backup existing StatefulSet`, shouldBackupExistingStatefulSet)
 		})
 	})
 
@@ -332,7 +389,7 @@ var _ = Describe("StatefulSet", func() {
 			cred = f.SecretForLocalBackend()
 			restic = f.ResticForLocalBackend()
 		})
-		XIt(`should stop backup`, shouldStopBackupIfLabelChanged)
+		It(`should stop backup`, shouldStopBackupIfLabelChanged)
 	})
 
 	Describe("Changing Restic selector", func() {
@@ -346,7 +403,7 @@ var _ = Describe("StatefulSet", func() {
 			cred = f.SecretForLocalBackend()
 			restic = f.ResticForLocalBackend()
 		})
-		XIt(`should stop backup`, shouldStopBackupIfSelectorChanged)
+		It(`should stop backup`, shouldStopBackupIfSelectorChanged)
 	})
 
 	Describe("Deleting restic for", func() {
@@ -361,7 +418,7 @@ var _ = Describe("StatefulSet", func() {
 				cred = f.SecretForLocalBackend()
 				restic = f.ResticForLocalBackend()
 			})
-			XIt(`should stop backup`, shouldStopBackup)
+			It(`should stop backup`, shouldStopBackup)
 		})
 
 		Context(`"S3" backend`, func() {
@@ -369,7 +426,7 @@ var _ = Describe("StatefulSet", func() {
 				cred = f.SecretForS3Backend()
 				restic = f.ResticForS3Backend()
 			})
-			XIt(`should stop backup`, shouldStopBackup)
+			It(`should stop backup`, shouldStopBackup)
 		})
 
 		Context(`"DO" backend`, func() {
@@ -377,7 +434,7 @@ var _ = Describe("StatefulSet", func() {
 				cred = f.SecretForDOBackend()
 				restic = f.ResticForDOBackend()
 			})
-			XIt(`should stop backup`, shouldStopBackup)
+			It(`should stop backup`, shouldStopBackup)
 		})
 
 		Context(`"GCS" backend`, func() {
@@ -385,7 +442,7 @@ var _ = Describe("StatefulSet", func() {
 				cred = f.SecretForGCSBackend()
 				restic = f.ResticForGCSBackend()
 			})
-			XIt(`should stop backup`, shouldStopBackup)
+			It(`should stop backup`, shouldStopBackup)
 		})
 
 		Context(`"Azure" backend`, func() {
@@ -393,7 +450,7 @@ var _ = Describe("StatefulSet", func() {
 				cred = f.SecretForAzureBackend()
 				restic = f.ResticForAzureBackend()
 			})
-			XIt(`should stop backup`, shouldStopBackup)
+			It(`should stop backup`, shouldStopBackup)
 		})
 
 		Context(`"Swift" backend`, func() {
@@ -401,7 +458,7 @@ var _ = Describe("StatefulSet", func() {
 				cred = f.SecretForSwiftBackend()
 				restic = f.ResticForSwiftBackend()
 			})
-			XIt(`should stop backup`, shouldStopBackup)
+			It(`should stop backup`, shouldStopBackup)
 		})
 
 		Context(`"B2" backend`, func() {
@@ -409,8 +466,22 @@ var _ = Describe("StatefulSet", func() {
 				cred = f.SecretForB2Backend()
 				restic = f.ResticForB2Backend()
 			})
-			XIt(`should stop backup`, shouldStopBackup)
+			It(`should stop backup`, shouldStopBackup)
+		})
+	})
+
+	Describe("StatefulSet with OnDelete update strategy", func() {
+		AfterEach(func() {
+			f.DeleteStatefulSet(ss.ObjectMeta)
+			f.DeleteService(svc.ObjectMeta)
+			f.DeleteRestic(restic.ObjectMeta)
+			f.DeleteSecret(cred.ObjectMeta)
+		})
+		BeforeEach(func() {
+			cred = f.SecretForLocalBackend()
+			restic = f.ResticForLocalBackend()
 		})
+		It(`should add sidecar to pods one by one`, shouldReplacePodsOneByOne)
 	})
 
 	Describe("Creating recovery for", func() {